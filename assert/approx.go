@@ -0,0 +1,159 @@
+package assert
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// EqualApprox asserts that two values are equal, treating floating-point
+// numbers anywhere inside them (struct fields, slice elements, map values) as
+// equal when they differ by at most delta. All other values must be exactly
+// equal. On failure the path to the first differing value is reported.
+func EqualApprox(t TestingT, expected, actual any, delta float64, msg ...string) {
+	t.Helper()
+
+	diff, ok := approxEqual(reflect.ValueOf(expected), reflect.ValueOf(actual), compareOptions{delta: delta}, "")
+	if ok {
+		return
+	}
+
+	message := messageOrDefault(msg, "values should be approximately equal")
+	t.Errorf("%s\nPath:     %s\nExpected: %s\nActual:   %s\nDelta:    %v", message, diff.displayPath(), formatValue(expected), formatValue(actual), delta)
+}
+
+// difference locates the first difference found by approxEqual.
+type difference struct {
+	path             string
+	expected, actual reflect.Value
+	missing          bool // the map key at path is absent from actual
+}
+
+// displayPath returns the path without its leading dot, or "(root)".
+func (d difference) displayPath() string {
+	if d.path == "" {
+		return "(root)"
+	}
+	return strings.TrimPrefix(d.path, ".")
+}
+
+// compareOptions relaxes the comparison made by approxEqual.
+type compareOptions struct {
+	delta          float64 // tolerance for floating-point values
+	nilEqualsEmpty bool    // treat nil and empty slices and maps as equal
+	skipSync       bool    // ignore struct fields holding sync primitives
+}
+
+// visit is a pair of pointers compared by approxEqual, recorded so that
+// cyclic values do not recurse forever.
+type visit struct {
+	a, b uintptr
+	typ  reflect.Type
+}
+
+// approxEqual compares a and b recursively and returns the path of the first
+// difference found, along with the values found there. Map entries are
+// visited in sorted key order so the reported difference is deterministic.
+// Like reflect.DeepEqual, it treats a pair of pointers, maps or slices that is
+// already being compared further up as equal, so cyclic values terminate.
+func approxEqual(a, b reflect.Value, opts compareOptions, path string) (difference, bool) {
+	return approxEqualVisited(a, b, opts, path, make(map[visit]bool))
+}
+
+// seen reports whether the pair a, b is already being compared, and records
+// it otherwise.
+func seen(a, b reflect.Value, visited map[visit]bool) bool {
+	v := visit{a.Pointer(), b.Pointer(), a.Type()}
+	if visited[v] {
+		return true
+	}
+	visited[v] = true
+	return false
+}
+
+func approxEqualVisited(a, b reflect.Value, opts compareOptions, path string, visited map[visit]bool) (difference, bool) {
+	at := func(ok bool) (difference, bool) {
+		return difference{path: path, expected: a, actual: b}, ok
+	}
+
+	if !a.IsValid() || !b.IsValid() {
+		return at(a.IsValid() == b.IsValid())
+	}
+	if a.Type() != b.Type() {
+		return at(false)
+	}
+
+	if a.Type() == reflect.TypeOf(time.Time{}) && a.CanInterface() {
+		return at(a.Interface().(time.Time).Equal(b.Interface().(time.Time)))
+	}
+
+	switch a.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return at(math.Abs(a.Float()-b.Float()) <= opts.delta)
+	case reflect.Bool:
+		return at(a.Bool() == b.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return at(a.Int() == b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return at(a.Uint() == b.Uint())
+	case reflect.Complex64, reflect.Complex128:
+		return at(a.Complex() == b.Complex())
+	case reflect.String:
+		return at(a.String() == b.String())
+	case reflect.Ptr, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return at(a.IsNil() == b.IsNil())
+		}
+		if a.Kind() == reflect.Ptr && seen(a, b, visited) {
+			return at(true)
+		}
+		return approxEqualVisited(a.Elem(), b.Elem(), opts, path, visited)
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if opts.skipSync && syncTypes[a.Type().Field(i).Type] {
+				continue
+			}
+			fieldPath := path + "." + a.Type().Field(i).Name
+			if d, ok := approxEqualVisited(a.Field(i), b.Field(i), opts, fieldPath, visited); !ok {
+				return d, false
+			}
+		}
+		return at(true)
+	case reflect.Slice, reflect.Array:
+		if a.Kind() == reflect.Slice && a.IsNil() != b.IsNil() && !opts.nilEqualsEmpty || a.Len() != b.Len() {
+			return at(false)
+		}
+		if a.Kind() == reflect.Slice && a.Len() > 0 && seen(a, b, visited) {
+			return at(true)
+		}
+		for i := 0; i < a.Len(); i++ {
+			if d, ok := approxEqualVisited(a.Index(i), b.Index(i), opts, fmt.Sprintf("%s[%d]", path, i), visited); !ok {
+				return d, false
+			}
+		}
+		return at(true)
+	case reflect.Map:
+		if a.IsNil() != b.IsNil() && !opts.nilEqualsEmpty || a.Len() != b.Len() {
+			return at(false)
+		}
+		if a.Len() > 0 && seen(a, b, visited) {
+			return at(true)
+		}
+		for _, key := range sortedKeys(a) {
+			keyPath := fmt.Sprintf("%s[%#v]", path, key)
+			other := b.MapIndex(key)
+			if !other.IsValid() {
+				return difference{path: keyPath, expected: a.MapIndex(key), missing: true}, false
+			}
+			if d, ok := approxEqualVisited(a.MapIndex(key), other, opts, keyPath, visited); !ok {
+				return d, false
+			}
+		}
+		return at(true)
+	default:
+		// Channels, functions and unsafe pointers are compared by identity.
+		return at(a.Pointer() == b.Pointer())
+	}
+}
@@ -0,0 +1,99 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+type lineItem struct {
+	SKU   string
+	Price float64
+}
+
+type order struct {
+	ID    string
+	Items []lineItem
+	Total float64
+}
+
+// TestEqualApproxToleratesRoundingError tests float fields differing by a rounding error.
+func TestEqualApproxToleratesRoundingError(t *testing.T) {
+	a, b := 0.1, 0.2
+	expected := order{ID: "o-1", Items: []lineItem{{SKU: "a", Price: 0.3}}, Total: 0.3}
+	actual := order{ID: "o-1", Items: []lineItem{{SKU: "a", Price: a + b}}, Total: a + b}
+
+	rec := &recordingT{}
+	Equal(rec, expected, actual)
+	if !rec.failed() {
+		t.Fatal("Expected Equal to reject the rounding error")
+	}
+
+	rec = &recordingT{}
+	EqualApprox(rec, expected, actual, 1e-9)
+	if rec.failed() {
+		t.Errorf("Expected EqualApprox to tolerate the rounding error, got %v", rec.errors)
+	}
+}
+
+// TestEqualApproxReportsPath tests the path to a float beyond tolerance and
+// exact comparison of other fields.
+func TestEqualApproxReportsPath(t *testing.T) {
+	expected := order{ID: "o-1", Items: []lineItem{{SKU: "a", Price: 1}, {SKU: "b", Price: 2}}}
+	actual := order{ID: "o-1", Items: []lineItem{{SKU: "a", Price: 1}, {SKU: "b", Price: 2.5}}}
+
+	rec := &recordingT{}
+	EqualApprox(rec, expected, actual, 0.1)
+	if !rec.failed() || !strings.Contains(rec.errors[0], "Path:     Items[1].Price") {
+		t.Errorf("Expected the path of the differing price, got %v", rec.errors)
+	}
+
+	actual = order{ID: "o-2", Items: expected.Items}
+	rec = &recordingT{}
+	EqualApprox(rec, expected, actual, 0.1)
+	if !rec.failed() || !strings.Contains(rec.errors[0], "Path:     ID") {
+		t.Errorf("Expected non-float fields to compare exactly, got %v", rec.errors)
+	}
+
+	rec = &recordingT{}
+	EqualApprox(rec, map[string]float64{"a": 1}, map[string]float64{"a": 1.05}, 0.1)
+	EqualApprox(rec, 1.0, 1.2, 0.1)
+	if len(rec.errors) != 1 || !strings.Contains(rec.errors[0], "Path:     (root)") {
+		t.Errorf("Expected only the scalar comparison to fail at the root, got %v", rec.errors)
+	}
+}
+
+type ring struct {
+	Value float64
+	Next  *ring
+}
+
+// newRing returns a ring of nodes holding values, whose last node points back
+// to the first.
+func newRing(values ...float64) *ring {
+	head := &ring{Value: values[0]}
+	node := head
+	for _, value := range values[1:] {
+		node.Next = &ring{Value: value}
+		node = node.Next
+	}
+	node.Next = head
+	return head
+}
+
+// TestApproxEqualCyclicValues tests that cyclic values are compared without
+// recursing forever.
+func TestApproxEqualCyclicValues(t *testing.T) {
+	rec := &recordingT{}
+	DeepEqual(rec, newRing(1, 2, 3), newRing(1, 2, 3))
+	EqualApprox(rec, newRing(1, 2, 3), newRing(1, 2.05, 3), 0.1)
+	EqualLenient(rec, newRing(1, 2), newRing(1, 2))
+	if rec.failed() {
+		t.Errorf("Expected equal cyclic values to pass, got %v", rec.errors)
+	}
+
+	rec = &recordingT{}
+	DeepEqual(rec, newRing(1, 2, 3), newRing(1, 2, 4))
+	if !rec.failed() || !strings.Contains(rec.errors[0], "Next.Next.Value") {
+		t.Errorf("Expected the path of the differing node, got %v", rec.errors)
+	}
+}
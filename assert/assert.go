@@ -1,16 +1,41 @@
 package assert
 
 import (
+	"fmt"
 	"reflect"
-	"testing"
+	"time"
 )
 
+// TestingT is the subset of *testing.T used by the assertions. Accepting an
+// interface lets the assertions be exercised against a recorder in tests.
+// testing.TB satisfies it, so assertions also work with *testing.B, for
+// example to check results in the setup phase of a benchmark.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
 // Equal asserts that two values are equal. If they are not equal, it calls t.Errorf.
 // The optional msg parameter allows for a custom error message.
-func Equal(t *testing.T, expected, actual any, msg ...string) {
+//
+// Values are compared by the first rule that applies: time.Time values by
+// instant; non-nil errors by their Error() message; *big.Int, *big.Rat and
+// *big.Float values by value with Cmp; values of the same type that declare
+// an Equal(T) bool method, such as net.IP, with that method; channels by
+// identity, so they are equal only if they are the same channel or both nil
+// channels of the same type; values containing struct fields that hold a
+// sync.Mutex, sync.RWMutex, sync.Once or sync.WaitGroup, including embedded
+// ones, field by field without those fields, since whether a lock is held says
+// nothing about the value (fields holding a pointer such as *sync.Mutex are
+// still compared); and everything else with reflect.DeepEqual. Pass structs
+// holding locks by pointer so that go vet does not report copying them.
+//
+// Long or multi-line strings that differ are reported around the line and
+// column of their first difference instead of in full.
+func Equal(t TestingT, expected, actual any, msg ...string) {
 	t.Helper()
 	
-	if !reflect.DeepEqual(expected, actual) {
+	if !objectsAreEqual(expected, actual) {
 		var message string
 		if len(msg) > 0 && msg[0] != "" {
 			message = msg[0]
@@ -18,15 +43,15 @@ func Equal(t *testing.T, expected, actual any, msg ...string) {
 			message = "values should be equal"
 		}
 		
-		t.Errorf("%s\nExpected: %v\nActual:   %v", message, expected, actual)
+		t.Errorf("%s\n%s", message, equalFailureDetails(expected, actual))
 	}
 }
 
 // NotEqual asserts that two values are not equal. If they are equal, it calls t.Errorf.
-func NotEqual(t *testing.T, expected, actual any, msg ...string) {
+func NotEqual(t TestingT, expected, actual any, msg ...string) {
 	t.Helper()
 	
-	if reflect.DeepEqual(expected, actual) {
+	if objectsAreEqual(expected, actual) {
 		var message string
 		if len(msg) > 0 && msg[0] != "" {
 			message = msg[0]
@@ -34,12 +59,12 @@ func NotEqual(t *testing.T, expected, actual any, msg ...string) {
 			message = "values should not be equal"
 		}
 		
-		t.Errorf("%s\nBoth values: %v", message, expected)
+		t.Errorf("%s\nBoth values: %s", message, formatValue(expected))
 	}
 }
 
 // True asserts that the given value is true.
-func True(t *testing.T, value bool, msg ...string) {
+func True(t TestingT, value bool, msg ...string) {
 	t.Helper()
 	
 	if !value {
@@ -55,7 +80,7 @@ func True(t *testing.T, value bool, msg ...string) {
 }
 
 // False asserts that the given value is false.
-func False(t *testing.T, value bool, msg ...string) {
+func False(t TestingT, value bool, msg ...string) {
 	t.Helper()
 	
 	if value {
@@ -70,8 +95,19 @@ func False(t *testing.T, value bool, msg ...string) {
 	}
 }
 
+// That asserts that predicate holds for value. Unlike True, the failure
+// message shows the value the predicate was applied to.
+func That(t TestingT, value any, predicate func(any) bool, msg ...string) {
+	t.Helper()
+
+	if !predicate(value) {
+		message := messageOrDefault(msg, "value does not satisfy predicate")
+		t.Errorf("%s\nValue: %s", message, formatValue(value))
+	}
+}
+
 // Nil asserts that the given value is nil.
-func Nil(t *testing.T, value any, msg ...string) {
+func Nil(t TestingT, value any, msg ...string) {
 	t.Helper()
 	
 	if value != nil && !reflect.ValueOf(value).IsNil() {
@@ -82,12 +118,12 @@ func Nil(t *testing.T, value any, msg ...string) {
 			message = "expected nil value"
 		}
 		
-		t.Errorf("%s\nGot: %v", message, value)
+		t.Errorf("%s\nGot: %s", message, formatValue(value))
 	}
 }
 
 // NotNil asserts that the given value is not nil.
-func NotNil(t *testing.T, value any, msg ...string) {
+func NotNil(t TestingT, value any, msg ...string) {
 	t.Helper()
 	
 	if value == nil || (reflect.ValueOf(value).Kind() == reflect.Ptr && reflect.ValueOf(value).IsNil()) {
@@ -100,4 +136,286 @@ func NotNil(t *testing.T, value any, msg ...string) {
 		
 		t.Errorf(message)
 	}
-}
\ No newline at end of file
+}
+
+// EqualWith asserts that two values are equal according to the supplied comparator
+// instead of reflect.DeepEqual.
+func EqualWith(t TestingT, expected, actual any, cmp func(a, b any) bool, msg ...string) {
+	t.Helper()
+
+	if !cmp(expected, actual) {
+		message := messageOrDefault(msg, "values should be equal according to comparator")
+		t.Errorf("%s\nExpected: %s\nActual:   %s", message, formatValue(expected), formatValue(actual))
+	}
+}
+
+// EqualTime asserts that two times represent the same instant, regardless of
+// their location or monotonic clock reading.
+func EqualTime(t TestingT, expected, actual time.Time, msg ...string) {
+	t.Helper()
+
+	if !expected.Equal(actual) {
+		message := messageOrDefault(msg, "times should represent the same instant")
+		t.Errorf("%s\nExpected: %s\nActual:   %s", message, formatValue(expected), formatValue(actual))
+	}
+}
+
+// EqualDeref asserts that two values are equal like Equal, but first
+// dereferences a pointer compared against a value of its element type, so
+// User{ID: "1"} equals &User{ID: "1"}. A nil pointer never equals a value,
+// not even the zero value.
+func EqualDeref(t TestingT, expected, actual any, msg ...string) {
+	t.Helper()
+
+	exp, act, nilPointer := derefPair(expected, actual)
+	if nilPointer || !objectsAreEqual(exp, act) {
+		message := messageOrDefault(msg, "values should be equal")
+		details := equalFailureDetails(exp, act)
+		if nilPointer {
+			details += "\nNote: a nil pointer does not equal a value of its element type"
+		}
+		t.Errorf("%s\n%s", message, details)
+	}
+}
+
+// derefPair dereferences whichever of a and b is a pointer to the other's
+// type. It reports whether that pointer was nil.
+func derefPair(a, b any) (any, any, bool) {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !va.IsValid() || !vb.IsValid() {
+		return a, b, false
+	}
+
+	if va.Kind() == reflect.Ptr && va.Type().Elem() == vb.Type() {
+		if va.IsNil() {
+			return a, b, true
+		}
+		return va.Elem().Interface(), b, false
+	}
+	if vb.Kind() == reflect.Ptr && vb.Type().Elem() == va.Type() {
+		if vb.IsNil() {
+			return a, b, true
+		}
+		return a, vb.Elem().Interface(), false
+	}
+	return a, b, false
+}
+
+// DeepEqual asserts that two values are deeply equal, walking structs,
+// slices, maps and pointers. Unlike Equal, a failure names the path to the
+// first difference, such as Users[2].Address.City, along with the values
+// found there. Map entries are visited in sorted key order.
+func DeepEqual(t TestingT, expected, actual any, msg ...string) {
+	t.Helper()
+
+	diff, ok := approxEqual(reflect.ValueOf(expected), reflect.ValueOf(actual), compareOptions{}, "")
+	if ok {
+		return
+	}
+
+	actualText := describeReflectValue(diff.actual)
+	if diff.missing {
+		actualText = "<missing>"
+	}
+	message := messageOrDefault(msg, "values should be deeply equal")
+	t.Errorf("%s\nPath:     %s\nExpected: %s\nActual:   %s", message, diff.displayPath(), describeReflectValue(diff.expected), actualText)
+}
+
+// EqualLenient asserts that two values are equal like Equal, except that a nil
+// slice or map equals an empty one of the same type, at any depth. This
+// avoids false failures when comparing values decoded from JSON with values
+// built in code.
+func EqualLenient(t TestingT, expected, actual any, msg ...string) {
+	t.Helper()
+
+	if objectsAreEqual(expected, actual) {
+		return
+	}
+	if _, ok := approxEqual(reflect.ValueOf(expected), reflect.ValueOf(actual), compareOptions{nilEqualsEmpty: true}, ""); ok {
+		return
+	}
+
+	message := messageOrDefault(msg, "values should be equal")
+	t.Errorf("%s\n%s", message, equalFailureDetails(expected, actual))
+}
+
+// describeReflectValue formats v like formatValue, falling back to fmt for
+// values read from unexported fields.
+func describeReflectValue(v reflect.Value) string {
+	switch {
+	case !v.IsValid():
+		return "<nil>"
+	case v.CanInterface():
+		return formatValue(v.Interface())
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// PanicValue asserts that fn panics and returns the recovered value so it can be
+// inspected with further assertions. It returns nil if fn did not panic.
+func PanicValue(t TestingT, fn func(), msg ...string) any {
+	t.Helper()
+
+	panicked, value := didPanic(fn)
+	if !panicked {
+		message := messageOrDefault(msg, "expected function to panic")
+		t.Errorf(message)
+		return nil
+	}
+
+	return value
+}
+
+// didPanic runs fn and reports whether it panicked, along with the recovered value.
+func didPanic(fn func()) (panicked bool, value any) {
+	panicked = true
+
+	defer func() {
+		value = recover()
+	}()
+
+	fn()
+	panicked = false
+	return
+}
+
+// objectsAreEqual reports whether two values are equal, applying the rules
+// documented on Equal in order: time.Time values by instant, errors by their
+// Error() message, big numbers with Cmp, types with an Equal method by that
+// method, channels by identity, values containing sync primitives without
+// them, and everything else with reflect.DeepEqual.
+func objectsAreEqual(expected, actual any) bool {
+	if exp, ok := expected.(time.Time); ok {
+		if act, ok := actual.(time.Time); ok {
+			return exp.Equal(act)
+		}
+	}
+
+	if exp, act, ok := bothErrors(expected, actual); ok {
+		return exp.Error() == act.Error()
+	}
+
+	if cmp, ok := bigCmp(expected, actual); ok {
+		return cmp == 0
+	}
+
+	if equal, ok := equalMethod(expected, actual); ok {
+		return equal.Call([]reflect.Value{reflect.ValueOf(actual)})[0].Bool()
+	}
+
+	if bothChannels(expected, actual) {
+		exp, act := reflect.ValueOf(expected), reflect.ValueOf(actual)
+		return exp.Type() == act.Type() && exp.Pointer() == act.Pointer()
+	}
+
+	if exp, act := reflect.ValueOf(expected), reflect.ValueOf(actual); exp.IsValid() && act.IsValid() &&
+		exp.Type() == act.Type() && hasSyncField(exp.Type()) {
+		_, ok := approxEqual(exp, act, compareOptions{skipSync: true}, "")
+		return ok
+	}
+
+	return reflect.DeepEqual(expected, actual)
+}
+
+// equalMethod returns the Equal method of expected if it can compare against
+// actual. To stay predictable it only applies when both values have the same
+// type, neither is a nil pointer, and the method has exactly the signature
+// Equal(T) bool.
+func equalMethod(expected, actual any) (reflect.Value, bool) {
+	exp, act := reflect.ValueOf(expected), reflect.ValueOf(actual)
+	if !exp.IsValid() || !act.IsValid() || exp.Type() != act.Type() {
+		return reflect.Value{}, false
+	}
+	if exp.Kind() == reflect.Ptr && (exp.IsNil() || act.IsNil()) {
+		return reflect.Value{}, false
+	}
+
+	method := exp.MethodByName("Equal")
+	if !method.IsValid() {
+		return reflect.Value{}, false
+	}
+	typ := method.Type()
+	if typ.NumIn() != 1 || typ.IsVariadic() || typ.In(0) != exp.Type() ||
+		typ.NumOut() != 1 || typ.Out(0).Kind() != reflect.Bool {
+		return reflect.Value{}, false
+	}
+	return method, true
+}
+
+// bothChannels reports whether expected and actual are both channels.
+func bothChannels(expected, actual any) bool {
+	return reflect.ValueOf(expected).Kind() == reflect.Chan && reflect.ValueOf(actual).Kind() == reflect.Chan
+}
+
+// bothErrors returns expected and actual as errors if both are non-nil errors.
+// Typed nils such as (*MyErr)(nil) don't count, since calling Error on them
+// may panic; they are left to reflect.DeepEqual.
+func bothErrors(expected, actual any) (error, error, bool) {
+	exp, ok := expected.(error)
+	if !ok || isNilValue(exp) {
+		return nil, nil, false
+	}
+	act, ok := actual.(error)
+	if !ok || isNilValue(act) {
+		return nil, nil, false
+	}
+	return exp, act, true
+}
+
+// isNilValue reports whether v is nil or a typed nil pointer, map, slice,
+// channel, function or interface.
+func isNilValue(v any) bool {
+	value := reflect.ValueOf(v)
+	switch value.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface:
+		return value.IsNil()
+	}
+	return false
+}
+
+// equalFailureDetails describes the difference between two unequal values.
+func equalFailureDetails(expected, actual any) string {
+	if a, b, ok := bothBytes(expected, actual); ok {
+		return bytesFailureDetails(a, b)
+	}
+
+	if a, ok := expected.(string); ok {
+		if b, ok := actual.(string); ok {
+			if details := stringFailureDetails(a, b); details != "" {
+				return details
+			}
+		}
+	}
+
+	if a, b := reflect.ValueOf(expected), reflect.ValueOf(actual); isList(a) && isList(b) {
+		return listFailureDetails(a, b)
+	}
+
+	if _, ok := bigCmp(expected, actual); ok {
+		return fmt.Sprintf("Expected: %s\nActual:   %s\nNote: compared with the Cmp method of %T", bigString(expected), bigString(actual), expected)
+	}
+
+	details := fmt.Sprintf("Expected: %s\nActual:   %s", formatValue(expected), formatValue(actual))
+
+	if _, _, ok := bothErrors(expected, actual); ok {
+		details += "\nNote: errors are compared by their Error() message; use errors.Is to compare identity"
+	} else if bothChannels(expected, actual) {
+		details += "\nNote: channels are equal only if they are the same channel, or both nil"
+	} else if _, ok := equalMethod(expected, actual); ok {
+		details += fmt.Sprintf("\nNote: compared with the Equal method of %T", expected)
+	}
+
+	return details
+}
+
+// messageOrDefault returns the custom message if one was given, otherwise the
+// default message.
+func messageOrDefault(msg []string, defaultMessage string) string {
+	if len(msg) > 0 && msg[0] != "" {
+		return msg[0]
+	}
+	return defaultMessage
+}
@@ -0,0 +1,254 @@
+package assert
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestEqualTimeSameInstant tests that times in different locations compare by instant.
+func TestEqualTimeSameInstant(t *testing.T) {
+	utc := time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC)
+	rome := utc.In(time.FixedZone("CET", 60*60))
+
+	if !objectsAreEqual(utc, rome) {
+		t.Error("Expected times representing the same instant to be equal")
+	}
+
+	if objectsAreEqual(utc, rome.Add(time.Second)) {
+		t.Error("Expected times representing different instants to differ")
+	}
+
+	Equal(t, utc, rome, "Equal should compare times by instant")
+	EqualTime(t, utc, rome, "EqualTime should compare times by instant")
+}
+
+// recordingT is a TestingT that records failures instead of reporting them.
+type recordingT struct {
+	errors []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingT) failed() bool {
+	return len(r.errors) > 0
+}
+
+type user struct {
+	ID    string
+	Name  string
+	Email string
+}
+
+// TestEqualWith tests that a custom comparator ignoring a field is used.
+func TestEqualWith(t *testing.T) {
+	ignoreID := func(a, b any) bool {
+		ua, ub := *a.(*user), *b.(*user)
+		ua.ID, ub.ID = "", ""
+		return ua == ub
+	}
+
+	expected := &user{ID: "1", Name: "John Doe", Email: "john@example.com"}
+
+	rec := &recordingT{}
+	EqualWith(rec, expected, &user{ID: "2", Name: "John Doe", Email: "john@example.com"}, ignoreID)
+	if rec.failed() {
+		t.Errorf("Expected users differing only by ID to be equal, got %v", rec.errors)
+	}
+
+	rec = &recordingT{}
+	EqualWith(rec, expected, &user{ID: "1", Name: "Jane Doe", Email: "john@example.com"}, ignoreID)
+	if !rec.failed() {
+		t.Error("Expected users with different names to fail")
+	}
+}
+
+type validationError struct {
+	Field string
+}
+
+func (e *validationError) Error() string {
+	return "invalid " + e.Field
+}
+
+// TestPanicValue tests that the recovered value is returned for inspection.
+func TestPanicValue(t *testing.T) {
+	rec := &recordingT{}
+	value := PanicValue(rec, func() {
+		panic(&validationError{Field: "email"})
+	})
+
+	if rec.failed() {
+		t.Fatalf("Expected no failure, got %v", rec.errors)
+	}
+
+	err, ok := value.(*validationError)
+	if !ok {
+		t.Fatalf("Expected recovered value of type *validationError, got %T", value)
+	}
+
+	if err.Field != "email" {
+		t.Errorf("Expected Field 'email', got '%s'", err.Field)
+	}
+}
+
+// TestPanicValueNoPanic tests that a function that does not panic fails.
+func TestPanicValueNoPanic(t *testing.T) {
+	rec := &recordingT{}
+	value := PanicValue(rec, func() {})
+
+	if !rec.failed() {
+		t.Error("Expected a failure when the function does not panic")
+	}
+
+	if value != nil {
+		t.Errorf("Expected nil value, got %v", value)
+	}
+}
+
+// TestThat tests that a failing predicate reports the inspected value.
+func TestThat(t *testing.T) {
+	hasCompanyEmail := func(v any) bool {
+		return strings.HasSuffix(v.(user).Email, "@example.com")
+	}
+
+	rec := &recordingT{}
+	That(rec, user{ID: "1", Name: "Alice", Email: "alice@example.com"}, hasCompanyEmail)
+	if rec.failed() {
+		t.Errorf("Expected predicate to hold, got %v", rec.errors)
+	}
+
+	That(rec, user{ID: "2", Name: "Bob", Email: "bob@mail.test"}, hasCompanyEmail, "email must be a company address")
+	if len(rec.errors) != 1 {
+		t.Fatalf("Expected 1 failure, got %v", rec.errors)
+	}
+	if !strings.HasPrefix(rec.errors[0], "email must be a company address\n") || !strings.Contains(rec.errors[0], "bob@mail.test") {
+		t.Errorf("Expected failure to show the email, got:\n%s", rec.errors[0])
+	}
+}
+
+// TestEqualDeref tests that a value equals a pointer to an equal struct.
+func TestEqualDeref(t *testing.T) {
+	rec := &recordingT{}
+	EqualDeref(rec, user{ID: "1", Name: "Alice"}, &user{ID: "1", Name: "Alice"})
+	EqualDeref(rec, &user{ID: "1"}, user{ID: "1"})
+	if rec.failed() {
+		t.Fatalf("Expected a value to equal a pointer to it, got %v", rec.errors)
+	}
+
+	EqualDeref(rec, user{ID: "1"}, &user{ID: "2"})
+	if len(rec.errors) != 1 {
+		t.Fatalf("Expected 1 failure, got %v", rec.errors)
+	}
+
+	var missing *user
+	EqualDeref(rec, user{}, missing)
+	if len(rec.errors) != 2 || !strings.Contains(rec.errors[1], "nil pointer does not equal") {
+		t.Errorf("Expected a nil pointer not to match the zero value, got %v", rec.errors)
+	}
+}
+
+// caseless is a string type with its own semantic equality.
+type caseless string
+
+func (c caseless) Equal(other caseless) bool {
+	return strings.EqualFold(string(c), string(other))
+}
+
+// TestEqualUsesEqualMethod tests that Equal defers to a type's Equal method.
+func TestEqualUsesEqualMethod(t *testing.T) {
+	rec := &recordingT{}
+	Equal(rec, caseless("Alice"), caseless("ALICE"))
+	Equal(rec, net.ParseIP("127.0.0.1"), net.ParseIP("::ffff:127.0.0.1"))
+	if rec.failed() {
+		t.Fatalf("Expected values equal by their Equal method to pass, got %v", rec.errors)
+	}
+
+	Equal(rec, caseless("Alice"), caseless("Bob"))
+	if len(rec.errors) != 1 || !strings.Contains(rec.errors[0], "Note: compared with the Equal method of assert.caseless") {
+		t.Errorf("Expected a failure noting the Equal method, got %v", rec.errors)
+	}
+
+	Equal(rec, caseless("Alice"), "alice")
+	if len(rec.errors) != 2 {
+		t.Errorf("Expected values of different types not to use the Equal method, got %v", rec.errors)
+	}
+}
+
+type address struct {
+	Street string
+	City   string
+}
+
+type member struct {
+	Name    string
+	Address *address
+}
+
+type team struct {
+	Name    string
+	Members []*member
+}
+
+// TestDeepEqualNamesPath tests that a difference deep inside pointers, slices
+// and maps is reported with its full path.
+func TestDeepEqualNamesPath(t *testing.T) {
+	build := func(city string) map[string]*team {
+		return map[string]*team{
+			"backend": {Name: "Backend", Members: []*member{
+				{Name: "Alice", Address: &address{Street: "Via Roma", City: "Rome"}},
+				{Name: "Bob", Address: &address{Street: "Via Po", City: "Turin"}},
+				{Name: "Carol", Address: &address{Street: "Via Dante", City: city}},
+			}},
+			"frontend": {Name: "Frontend"},
+		}
+	}
+
+	rec := &recordingT{}
+	DeepEqual(rec, build("Milan"), build("Milan"))
+	if rec.failed() {
+		t.Fatalf("Expected equal structures to pass, got %v", rec.errors)
+	}
+
+	DeepEqual(rec, build("Milan"), build("Naples"))
+	if len(rec.errors) != 1 {
+		t.Fatalf("Expected 1 failure, got %v", rec.errors)
+	}
+	for _, want := range []string{
+		`Path:     ["backend"].Members[2].Address.City`,
+		"Expected: Milan\nActual:   Naples",
+	} {
+		if !strings.Contains(rec.errors[0], want) {
+			t.Errorf("Expected failure to contain %q, got:\n%s", want, rec.errors[0])
+		}
+	}
+
+	DeepEqual(rec, map[string]int{"a": 1, "b": 2}, map[string]int{"a": 1, "c": 2})
+	if len(rec.errors) != 2 || !strings.Contains(rec.errors[1], "Path:     [\"b\"]\nExpected: 2\nActual:   <missing>") {
+		t.Errorf("Expected the missing key to be reported, got %v", rec.errors)
+	}
+}
+
+// TestEqualLenient tests that nil and empty slices and maps are equal at any
+// depth, while other differences still fail.
+func TestEqualLenient(t *testing.T) {
+	rec := &recordingT{}
+	EqualLenient(rec, []int(nil), []int{})
+	EqualLenient(rec, map[string]int(nil), map[string]int{})
+	EqualLenient(rec, &team{Name: "Backend"}, &team{Name: "Backend", Members: []*member{}})
+	if rec.failed() {
+		t.Fatalf("Expected nil and empty collections to be equal, got %v", rec.errors)
+	}
+
+	EqualLenient(rec, []int(nil), []int{0})
+	EqualLenient(rec, []int{}, []string{})
+	if len(rec.errors) != 2 {
+		t.Errorf("Expected 2 failures, got %v", rec.errors)
+	}
+}
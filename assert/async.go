@@ -0,0 +1,98 @@
+package assert
+
+import (
+	"time"
+)
+
+// RetryUntil calls fn up to attempts times, doubling the wait between
+// attempts starting from backoff, and passes as soon as fn returns nil. If
+// every attempt fails, the error from the final attempt is reported.
+func RetryUntil(t TestingT, attempts int, backoff time.Duration, fn func() error, msg ...string) {
+	t.Helper()
+
+	if attempts < 1 {
+		t.Errorf("RetryUntil called with invalid attempts %d: at least one attempt is required", attempts)
+		return
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = fn(); err == nil {
+			return
+		}
+		if attempt < attempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+
+	message := messageOrDefault(msg, "condition not met after retries")
+	t.Errorf("%s\nAttempts:   %d\nLast error: %v", message, attempts, err)
+}
+
+// CompletesWithin asserts that fn returns within budget. fn runs in its own
+// goroutine; a panic in fn is reported as a failure.
+//
+// Go cannot stop a goroutine from the outside, so when the budget is exceeded
+// fn keeps running in the background until it returns. Functions that may
+// block forever should accept a context or another cancellation signal.
+func CompletesWithin(t TestingT, budget time.Duration, fn func(), msg ...string) {
+	t.Helper()
+
+	done := make(chan any, 1)
+	start := time.Now()
+	go func() {
+		defer func() {
+			done <- recover()
+		}()
+		fn()
+	}()
+
+	timer := time.NewTimer(budget)
+	defer timer.Stop()
+
+	select {
+	case panicValue := <-done:
+		if panicValue != nil {
+			message := messageOrDefault(msg, "function panicked")
+			t.Errorf("%s\nPanic:   %v\nElapsed: %v", message, panicValue, time.Since(start))
+		}
+	case <-timer.C:
+		message := messageOrDefault(msg, "function did not complete within budget")
+		t.Errorf("%s\nBudget:  %v\nElapsed: %v (still running)", message, budget, time.Since(start))
+	}
+}
+
+// Eventually asserts that condition returns true within waitFor, checking
+// it every tick.
+func Eventually(t TestingT, condition func() bool, waitFor, tick time.Duration, msg ...string) {
+	t.Helper()
+	EventuallyValue(t, func() (struct{}, bool) { return struct{}{}, condition() }, waitFor, tick, msg...)
+}
+
+// EventuallyValue polls fn every tick until it reports true, and returns the
+// value from that call. It fails and returns the last value produced if fn
+// does not succeed within waitFor.
+func EventuallyValue[T any](t TestingT, fn func() (T, bool), waitFor, tick time.Duration, msg ...string) T {
+	t.Helper()
+
+	deadline := time.Now().Add(waitFor)
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	attempts := 0
+	for {
+		attempts++
+		value, ok := fn()
+		if ok {
+			return value
+		}
+
+		if !time.Now().Before(deadline) {
+			message := messageOrDefault(msg, "condition not satisfied in time")
+			t.Errorf("%s\nWaited:     %v (%d attempts)\nLast value: %s", message, waitFor, attempts, formatValue(value))
+			return value
+		}
+		<-ticker.C
+	}
+}
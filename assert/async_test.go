@@ -0,0 +1,132 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRetryUntilSucceedsOnThirdAttempt tests that retries stop on success.
+func TestRetryUntilSucceedsOnThirdAttempt(t *testing.T) {
+	calls := 0
+	fn := func() error {
+		calls++
+		if calls < 3 {
+			return fmt.Errorf("user not replicated yet (attempt %d)", calls)
+		}
+		return nil
+	}
+
+	rec := &recordingT{}
+	RetryUntil(rec, 5, time.Millisecond, fn)
+
+	if rec.failed() {
+		t.Errorf("Expected success, got %v", rec.errors)
+	}
+
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+}
+
+// TestRetryUntilReportsLastError tests that the final error is surfaced.
+func TestRetryUntilReportsLastError(t *testing.T) {
+	calls := 0
+	start := time.Now()
+
+	rec := &recordingT{}
+	RetryUntil(rec, 3, 5*time.Millisecond, func() error {
+		calls++
+		return fmt.Errorf("attempt %d failed", calls)
+	})
+
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+
+	// Backoff doubles: 5ms + 10ms between the three attempts.
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Errorf("Expected exponential backoff of at least 15ms, took %v", elapsed)
+	}
+
+	if !rec.failed() || !strings.Contains(rec.errors[0], "Last error: attempt 3 failed") {
+		t.Errorf("Expected the last error to be reported, got %v", rec.errors)
+	}
+}
+
+// TestCompletesWithin tests a fast function passing and a slow one failing.
+func TestCompletesWithin(t *testing.T) {
+	rec := &recordingT{}
+	CompletesWithin(rec, time.Second, func() {})
+	if rec.failed() {
+		t.Errorf("Expected a fast function to pass, got %v", rec.errors)
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+
+	CompletesWithin(rec, 10*time.Millisecond, func() { <-release }, "lookup too slow")
+	if len(rec.errors) != 1 {
+		t.Fatalf("Expected 1 failure, got %v", rec.errors)
+	}
+	for _, want := range []string{"lookup too slow", "Budget:  10ms", "(still running)"} {
+		if !strings.Contains(rec.errors[0], want) {
+			t.Errorf("Expected failure to contain %q, got:\n%s", want, rec.errors[0])
+		}
+	}
+}
+
+// TestCompletesWithinPanic tests that a panic inside fn is reported.
+func TestCompletesWithinPanic(t *testing.T) {
+	rec := &recordingT{}
+	CompletesWithin(rec, time.Second, func() { panic("boom") })
+
+	if !rec.failed() || !strings.Contains(rec.errors[0], "Panic:   boom") {
+		t.Errorf("Expected the panic to be reported, got %v", rec.errors)
+	}
+}
+
+// TestEventuallyValueReturnsSatisfyingValue tests that the value from the
+// successful iteration is returned.
+func TestEventuallyValueReturnsSatisfyingValue(t *testing.T) {
+	store := map[string]*user{}
+	polls := 0
+	find := func() (*user, bool) {
+		polls++
+		if polls == 3 {
+			store["1"] = &user{ID: "1", Name: "Alice"}
+		}
+		u, ok := store["1"]
+		return u, ok
+	}
+
+	rec := &recordingT{}
+	u := EventuallyValue(rec, find, time.Second, time.Millisecond)
+
+	if rec.failed() {
+		t.Fatalf("Expected the user to appear, got %v", rec.errors)
+	}
+	if u == nil || u.Name != "Alice" || polls != 3 {
+		t.Errorf("Expected Alice from the third poll, got %v after %d polls", u, polls)
+	}
+}
+
+// TestEventuallyTimesOut tests the failure after waitFor elapses.
+func TestEventuallyTimesOut(t *testing.T) {
+	rec := &recordingT{}
+	count := EventuallyValue(rec, func() (int, bool) { return 42, false }, 10*time.Millisecond, time.Millisecond)
+
+	if !rec.failed() || !strings.Contains(rec.errors[0], "Last value: 42") {
+		t.Errorf("Expected a timeout failure showing the last value, got %v", rec.errors)
+	}
+	if count != 42 {
+		t.Errorf("Expected the last value to be returned, got %d", count)
+	}
+
+	rec = &recordingT{}
+	Eventually(rec, func() bool { return true }, 10*time.Millisecond, time.Millisecond)
+	if rec.failed() {
+		t.Errorf("Expected Eventually to pass, got %v", rec.errors)
+	}
+}
@@ -0,0 +1,28 @@
+package assert
+
+import "testing"
+
+// Both *testing.T and *testing.B are accepted wherever TestingT is.
+var _ TestingT = testing.TB(nil)
+
+// TestAssertionsInBenchmark tests that assertions work with a *testing.B: a
+// passing setup lets the benchmark run, a failing one stops it.
+func TestAssertionsInBenchmark(t *testing.T) {
+	failing := testing.Benchmark(func(b *testing.B) {
+		Equal(b, 42, 41, "setup should compute the answer")
+	})
+	if failing.N != 0 {
+		t.Errorf("Expected a failing assertion to stop the benchmark, got %d iterations", failing.N)
+	}
+
+	passing := testing.Benchmark(func(b *testing.B) {
+		Equal(b, 42, 6*7)
+		NoError(b, nil)
+		for i := 0; i < b.N; i++ {
+			_ = 6 * 7
+		}
+	})
+	if passing.N == 0 {
+		t.Error("Expected passing assertions to let the benchmark run")
+	}
+}
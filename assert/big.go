@@ -0,0 +1,39 @@
+package assert
+
+import "math/big"
+
+// bigCmp compares two non-nil *big.Int, *big.Rat or *big.Float values of the
+// same type with their Cmp method. reflect.DeepEqual looks at their internal
+// representation instead, which differs between equal values built with a
+// different precision or through different operations.
+func bigCmp(expected, actual any) (int, bool) {
+	switch exp := expected.(type) {
+	case *big.Int:
+		if act, ok := actual.(*big.Int); ok && exp != nil && act != nil {
+			return exp.Cmp(act), true
+		}
+	case *big.Rat:
+		if act, ok := actual.(*big.Rat); ok && exp != nil && act != nil {
+			return exp.Cmp(act), true
+		}
+	case *big.Float:
+		if act, ok := actual.(*big.Float); ok && exp != nil && act != nil {
+			return exp.Cmp(act), true
+		}
+	}
+	return 0, false
+}
+
+// bigString renders a big number exactly: integers and floats in decimal, and
+// rationals as a fraction unless they are integers.
+func bigString(value any) string {
+	switch v := value.(type) {
+	case *big.Int:
+		return v.String()
+	case *big.Rat:
+		return v.RatString()
+	case *big.Float:
+		return v.Text('g', -1)
+	}
+	return formatValue(value)
+}
@@ -0,0 +1,30 @@
+package assert
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// TestEqualBigNumbers tests that equal big numbers built differently are equal.
+func TestEqualBigNumbers(t *testing.T) {
+	rec := &recordingT{}
+	Equal(rec, big.NewInt(5), new(big.Int).Add(big.NewInt(2), big.NewInt(3)))
+	Equal(rec, big.NewRat(1, 2), new(big.Rat).SetFrac64(3, 6))
+	Equal(rec, big.NewFloat(0.5), new(big.Float).SetPrec(200).SetFloat64(0.5))
+	if rec.failed() {
+		t.Fatalf("Expected equal big numbers to pass, got %v", rec.errors)
+	}
+
+	Equal(rec, big.NewInt(5), big.NewInt(6))
+	Equal(rec, big.NewRat(1, 3), big.NewRat(2, 3))
+	if len(rec.errors) != 2 {
+		t.Fatalf("Expected 2 failures, got %v", rec.errors)
+	}
+	if !strings.Contains(rec.errors[0], "Expected: 5\nActual:   6\nNote: compared with the Cmp method of *big.Int") {
+		t.Errorf("Expected both decimal values, got:\n%s", rec.errors[0])
+	}
+	if !strings.Contains(rec.errors[1], "Expected: 1/3\nActual:   2/3") {
+		t.Errorf("Expected both fractions, got:\n%s", rec.errors[1])
+	}
+}
@@ -0,0 +1,66 @@
+package assert
+
+import (
+	"time"
+)
+
+// ChannelReceives asserts that a value arrives on ch within timeout and returns it.
+// The second result is false if the timeout fired or the channel was closed.
+func ChannelReceives[T any](t TestingT, ch <-chan T, timeout time.Duration, msg ...string) (T, bool) {
+	t.Helper()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	var zero T
+	select {
+	case value, ok := <-ch:
+		if !ok {
+			message := messageOrDefault(msg, "expected to receive a value but channel was closed")
+			t.Errorf(message)
+			return zero, false
+		}
+		return value, true
+	case <-timer.C:
+		message := messageOrDefault(msg, "expected to receive a value")
+		t.Errorf("%s\nNo value received within %v", message, timeout)
+		return zero, false
+	}
+}
+
+// ChannelClosed asserts that ch is closed. The check does not block; if a
+// value is buffered it is consumed and the assertion fails.
+func ChannelClosed[T any](t TestingT, ch <-chan T, msg ...string) {
+	t.Helper()
+
+	select {
+	case value, ok := <-ch:
+		if ok {
+			message := messageOrDefault(msg, "expected channel to be closed")
+			t.Errorf("%s\nReceived: %s", message, formatValue(value))
+		}
+	default:
+		message := messageOrDefault(msg, "expected channel to be closed")
+		t.Errorf(message)
+	}
+}
+
+// ChannelEmpty asserts that ch has no buffered values.
+func ChannelEmpty[T any](t TestingT, ch <-chan T, msg ...string) {
+	t.Helper()
+
+	if n := len(ch); n > 0 {
+		message := messageOrDefault(msg, "expected channel to be empty")
+		t.Errorf("%s\nBuffered values: %d", message, n)
+	}
+}
+
+// ChannelCapacity asserts that ch has a buffer of the given capacity.
+func ChannelCapacity[T any](t TestingT, ch <-chan T, capacity int, msg ...string) {
+	t.Helper()
+
+	if cap(ch) != capacity {
+		message := messageOrDefault(msg, "channel should have the expected capacity")
+		t.Errorf("%s\nExpected: %d\nActual:   %d", message, capacity, cap(ch))
+	}
+}
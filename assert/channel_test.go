@@ -0,0 +1,143 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestChannelReceivesValue tests that a value arriving in time is returned.
+func TestChannelReceivesValue(t *testing.T) {
+	ch := make(chan *user)
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		ch <- &user{ID: "1"}
+	}()
+
+	rec := &recordingT{}
+	got, ok := ChannelReceives(rec, ch, time.Second)
+
+	if rec.failed() || !ok {
+		t.Fatalf("Expected a value to be received, got %v", rec.errors)
+	}
+
+	if got.ID != "1" {
+		t.Errorf("Expected user 1, got %v", got)
+	}
+}
+
+// TestChannelReceivesTimeout tests that a timeout fails.
+func TestChannelReceivesTimeout(t *testing.T) {
+	ch := make(chan int)
+
+	rec := &recordingT{}
+	got, ok := ChannelReceives(rec, ch, 10*time.Millisecond)
+
+	if !rec.failed() || ok {
+		t.Fatal("Expected a failure on timeout")
+	}
+
+	if got != 0 {
+		t.Errorf("Expected zero value on timeout, got %d", got)
+	}
+
+	if !strings.Contains(rec.errors[0], "within 10ms") {
+		t.Errorf("Expected the timeout in the message, got %q", rec.errors[0])
+	}
+}
+
+// TestChannelReceivesClosed tests that a closed channel fails ChannelReceives.
+func TestChannelReceivesClosed(t *testing.T) {
+	ch := make(chan int)
+	close(ch)
+
+	rec := &recordingT{}
+	if _, ok := ChannelReceives(rec, ch, time.Second); ok || !rec.failed() {
+		t.Error("Expected a failure when the channel is closed")
+	}
+}
+
+// TestChannelClosed tests the closed channel assertion.
+func TestChannelClosed(t *testing.T) {
+	closed := make(chan int)
+	close(closed)
+
+	rec := &recordingT{}
+	ChannelClosed(rec, closed)
+	if rec.failed() {
+		t.Errorf("Expected closed channel to pass, got %v", rec.errors)
+	}
+
+	rec = &recordingT{}
+	ChannelClosed(rec, make(chan int))
+	if !rec.failed() {
+		t.Error("Expected open channel to fail")
+	}
+
+	buffered := make(chan int, 1)
+	buffered <- 7
+	rec = &recordingT{}
+	ChannelClosed(rec, buffered)
+	if !rec.failed() || !strings.Contains(rec.errors[0], "Received: 7") {
+		t.Errorf("Expected buffered value to be reported, got %v", rec.errors)
+	}
+}
+
+// TestChannelEmpty tests the empty channel assertion.
+func TestChannelEmpty(t *testing.T) {
+	ch := make(chan string, 2)
+
+	rec := &recordingT{}
+	ChannelEmpty(rec, ch)
+	if rec.failed() {
+		t.Errorf("Expected empty channel to pass, got %v", rec.errors)
+	}
+
+	ch <- "a"
+	rec = &recordingT{}
+	ChannelEmpty(rec, ch)
+	if !rec.failed() || !strings.Contains(rec.errors[0], "Buffered values: 1") {
+		t.Errorf("Expected buffered count to be reported, got %v", rec.errors)
+	}
+}
+
+// TestEqualChannels tests that channels compare by identity.
+func TestEqualChannels(t *testing.T) {
+	ch := make(chan int, 3)
+
+	rec := &recordingT{}
+	Equal(rec, ch, ch)
+	if rec.failed() {
+		t.Errorf("Expected a channel to equal itself, got %v", rec.errors)
+	}
+
+	rec = &recordingT{}
+	Equal(rec, ch, make(chan int, 3))
+	if !rec.failed() || !strings.Contains(rec.errors[0], "channels are equal only if they are the same channel") {
+		t.Errorf("Expected distinct channels to fail, got %v", rec.errors)
+	}
+
+	var a, b chan int
+	rec = &recordingT{}
+	Equal(rec, a, b)
+	if rec.failed() {
+		t.Errorf("Expected nil channels to be equal, got %v", rec.errors)
+	}
+}
+
+// TestChannelCapacity tests the channel capacity assertion.
+func TestChannelCapacity(t *testing.T) {
+	ch := make(chan int, 3)
+
+	rec := &recordingT{}
+	ChannelCapacity(rec, ch, 3)
+	if rec.failed() {
+		t.Errorf("Expected capacity 3 to pass, got %v", rec.errors)
+	}
+
+	rec = &recordingT{}
+	ChannelCapacity(rec, ch, 5)
+	if !rec.failed() || !strings.Contains(rec.errors[0], "Expected: 5\nActual:   3") {
+		t.Errorf("Expected capacity mismatch to be reported, got %v", rec.errors)
+	}
+}
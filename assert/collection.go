@@ -0,0 +1,395 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ElementsMatch asserts that two slices contain the same elements, ignoring order.
+// Elements that have no exact match but share a struct type with an unmatched
+// element on the other side are paired, and their differing fields are reported.
+func ElementsMatch(t TestingT, listA, listB any, msg ...string) {
+	t.Helper()
+
+	a, b := reflect.ValueOf(listA), reflect.ValueOf(listB)
+	if !isList(a) || !isList(b) {
+		message := messageOrDefault(msg, "ElementsMatch expects two slices or arrays")
+		t.Errorf("%s\nGot: %T and %T", message, listA, listB)
+		return
+	}
+
+	extraA, extraB := diffLists(a, b)
+	if len(extraA) == 0 && len(extraB) == 0 {
+		return
+	}
+
+	var report strings.Builder
+	usedB := make(map[int]bool)
+	for _, i := range extraA {
+		j, fields := closestElement(a.Index(i), b, extraB, usedB)
+		if j < 0 {
+			fmt.Fprintf(&report, "\nOnly in A: [%d] %#v", i, a.Index(i).Interface())
+			continue
+		}
+		usedB[j] = true
+		fmt.Fprintf(&report, "\nA[%d] and B[%d] differ:", i, j)
+		for _, field := range fields {
+			fmt.Fprintf(&report, "\n    %s", field)
+		}
+	}
+	for _, j := range extraB {
+		if !usedB[j] {
+			fmt.Fprintf(&report, "\nOnly in B: [%d] %#v", j, b.Index(j).Interface())
+		}
+	}
+
+	message := messageOrDefault(msg, "elements should match")
+	t.Errorf("%s%s", message, report.String())
+}
+
+// isList reports whether v is a slice or an array.
+func isList(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice || v.Kind() == reflect.Array
+}
+
+// diffLists returns the indexes of the elements of a without a match in b,
+// and of the elements of b without a match in a.
+func diffLists(a, b reflect.Value) (extraA, extraB []int) {
+	matched := make([]bool, b.Len())
+	for i := 0; i < a.Len(); i++ {
+		found := false
+		for j := 0; j < b.Len(); j++ {
+			if !matched[j] && objectsAreEqual(a.Index(i).Interface(), b.Index(j).Interface()) {
+				matched[j] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			extraA = append(extraA, i)
+		}
+	}
+	for j, ok := range matched {
+		if !ok {
+			extraB = append(extraB, j)
+		}
+	}
+	return extraA, extraB
+}
+
+// closestElement finds the unused candidate in b with the fewest differing
+// exported fields compared to elem. It returns -1 if none is comparable.
+func closestElement(elem, b reflect.Value, candidates []int, used map[int]bool) (int, []string) {
+	best, bestFields := -1, []string(nil)
+	for _, j := range candidates {
+		if used[j] {
+			continue
+		}
+		fields, ok := fieldDiffs(elem, b.Index(j))
+		if ok && (best < 0 || len(fields) < len(bestFields)) {
+			best, bestFields = j, fields
+		}
+	}
+	return best, bestFields
+}
+
+// fieldDiffs lists the exported fields that differ between two values of the
+// same struct type, following pointers and interfaces. It reports false if
+// the values are not comparable field by field.
+func fieldDiffs(a, b reflect.Value) ([]string, bool) {
+	a, b = indirect(a), indirect(b)
+	if !a.IsValid() || !b.IsValid() || a.Kind() != reflect.Struct || a.Type() != b.Type() {
+		return nil, false
+	}
+
+	var fields []string
+	for i := 0; i < a.NumField(); i++ {
+		field := a.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		av, bv := a.Field(i).Interface(), b.Field(i).Interface()
+		if !objectsAreEqual(av, bv) {
+			fields = append(fields, fmt.Sprintf("%s: %#v != %#v", field.Name, av, bv))
+		}
+	}
+	return fields, true
+}
+
+// indirect follows interfaces and non-nil pointers down to the underlying value.
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// ContainsValue asserts that the map m has at least one value equal to value.
+func ContainsValue(t TestingT, m any, value any, msg ...string) {
+	t.Helper()
+
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Map {
+		message := messageOrDefault(msg, "ContainsValue expects a map")
+		t.Errorf("%s\nGot: %T", message, m)
+		return
+	}
+
+	iter := v.MapRange()
+	for iter.Next() {
+		if objectsAreEqual(value, iter.Value().Interface()) {
+			return
+		}
+	}
+
+	message := messageOrDefault(msg, "map should contain value")
+	t.Errorf("%s\nMap:   %s\nValue: %s", message, formatValue(m), formatValue(value))
+}
+
+// IsSortedFunc asserts that slice is ordered according to less, i.e. no
+// element is less than the element before it. The first violating adjacent
+// pair is reported.
+func IsSortedFunc[T any](t TestingT, slice []T, less func(a, b T) bool, msg ...string) {
+	t.Helper()
+
+	for i := 1; i < len(slice); i++ {
+		if less(slice[i], slice[i-1]) {
+			message := messageOrDefault(msg, "slice should be sorted")
+			t.Errorf("%s\nElement [%d] %s is out of order after [%d] %s", message, i, formatValue(slice[i]), i-1, formatValue(slice[i-1]))
+			return
+		}
+	}
+}
+
+// ContainsOnce asserts that exactly one element of slice satisfies predicate,
+// as in "there is exactly one user named Alice". On failure it reports how
+// many elements matched and at which indices.
+func ContainsOnce[T any](t TestingT, slice []T, predicate func(T) bool, msg ...string) {
+	t.Helper()
+	containsN(t, slice, 1, predicate, messageOrDefault(msg, "slice should contain exactly one matching element"))
+}
+
+// ContainsN asserts that exactly n elements of slice satisfy predicate.
+func ContainsN[T any](t TestingT, slice []T, n int, predicate func(T) bool, msg ...string) {
+	t.Helper()
+	containsN(t, slice, n, predicate, messageOrDefault(msg, fmt.Sprintf("slice should contain exactly %d matching elements", n)))
+}
+
+func containsN[T any](t TestingT, slice []T, n int, predicate func(T) bool, message string) {
+	t.Helper()
+
+	var matches []string
+	for i, item := range slice {
+		if predicate(item) {
+			matches = append(matches, fmt.Sprintf("\n    [%d] %s", i, formatValue(item)))
+		}
+	}
+	if len(matches) == n {
+		return
+	}
+
+	t.Errorf("%s\nExpected count: %d\nActual count:   %d%s", message, n, len(matches), strings.Join(matches, ""))
+}
+
+// SharesBackingArray asserts that slices a and b share the same backing
+// array, as is the case for a slice and any sub-slice of it. Useful to verify
+// that code returns a view rather than a copy. Sharing is detected by
+// comparing the memory reachable through each slice's capacity, so slices
+// whose capacity was limited with a full slice expression may not overlap.
+func SharesBackingArray(t TestingT, a, b any, msg ...string) {
+	t.Helper()
+
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Kind() != reflect.Slice || vb.Kind() != reflect.Slice {
+		message := messageOrDefault(msg, "SharesBackingArray expects two slices")
+		t.Errorf("%s\nGot: %T and %T", message, a, b)
+		return
+	}
+
+	if !sharesBackingArray(va, vb) {
+		message := messageOrDefault(msg, "slices should share a backing array")
+		t.Errorf("%s\nA: %s (cap %d)\nB: %s (cap %d)", message, formatValue(a), va.Cap(), formatValue(b), vb.Cap())
+	}
+}
+
+// sharesBackingArray reports whether the memory reachable through the
+// capacity of two slices overlaps.
+func sharesBackingArray(a, b reflect.Value) bool {
+	if a.Cap() == 0 || b.Cap() == 0 || a.Type().Elem().Size() == 0 {
+		return false
+	}
+
+	startA, startB := a.Pointer(), b.Pointer()
+	endA := startA + uintptr(a.Cap())*a.Type().Elem().Size()
+	endB := startB + uintptr(b.Cap())*b.Type().Elem().Size()
+	return startA < endB && startB < endA
+}
+
+// NotAliased asserts that a and b, two maps or two slices of the same kind, do
+// not share storage, so mutating one cannot affect the other. Useful to verify
+// that a getter returns a defensive copy instead of exposing internal state.
+// Slices are compared like in SharesBackingArray; maps alias when they are the
+// same map.
+func NotAliased(t TestingT, a, b any, msg ...string) {
+	t.Helper()
+
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Kind() != vb.Kind() || (va.Kind() != reflect.Slice && va.Kind() != reflect.Map) {
+		message := messageOrDefault(msg, "NotAliased expects two maps or two slices")
+		t.Errorf("%s\nGot: %T and %T", message, a, b)
+		return
+	}
+
+	aliased := false
+	if va.Kind() == reflect.Map {
+		aliased = !va.IsNil() && va.Pointer() == vb.Pointer()
+	} else {
+		aliased = sharesBackingArray(va, vb)
+	}
+
+	if aliased {
+		message := messageOrDefault(msg, "values should not share storage")
+		t.Errorf("%s\nA: %s\nB: %s", message, formatValue(a), formatValue(b))
+	}
+}
+
+// Len asserts that object has the given length. object must be a string,
+// slice, array, map or channel.
+func Len(t TestingT, object any, length int, msg ...string) {
+	t.Helper()
+
+	if length < 0 {
+		t.Errorf("Len called with invalid expected length %d: lengths cannot be negative", length)
+		return
+	}
+
+	actual, ok := getLen(object)
+	if !ok {
+		message := messageOrDefault(msg, "object has no length")
+		t.Errorf("%s\nobject of type %T does not have a length", message, object)
+		return
+	}
+
+	if actual != length {
+		message := messageOrDefault(msg, "unexpected length")
+		t.Errorf("%s\nExpected length: %d\nActual length:   %d\nObject: %s", message, length, actual, formatValue(object))
+	}
+}
+
+// getLen returns the length of object if its kind has one.
+func getLen(object any) (int, bool) {
+	v := reflect.ValueOf(object)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		return v.Len(), true
+	}
+	return 0, false
+}
+
+// MapEqual asserts that two maps hold the same keys and values. On failure it
+// lists the keys missing from actual, the extra keys in actual, and both
+// values of every key whose values differ, in key order.
+func MapEqual(t TestingT, expected, actual any, msg ...string) {
+	t.Helper()
+
+	a, b := reflect.ValueOf(expected), reflect.ValueOf(actual)
+	if a.Kind() != reflect.Map || b.Kind() != reflect.Map || a.Type() != b.Type() {
+		message := messageOrDefault(msg, "MapEqual expects two maps of the same type")
+		t.Errorf("%s\nGot: %T and %T", message, expected, actual)
+		return
+	}
+
+	report := mapDiff(a, b, func(av, bv reflect.Value) bool {
+		return objectsAreEqual(av.Interface(), bv.Interface())
+	})
+	if report == "" {
+		return
+	}
+
+	message := messageOrDefault(msg, "maps should be equal")
+	t.Errorf("%s%s", message, report)
+}
+
+// EqualUnorderedMapValues asserts that two maps of slices hold the same keys
+// and, for every key, the same elements in any order, as in ElementsMatch.
+// This suits grouped results where the order within a group is irrelevant.
+// On failure it reports keys like MapEqual does.
+func EqualUnorderedMapValues(t TestingT, expected, actual any, msg ...string) {
+	t.Helper()
+
+	a, b := reflect.ValueOf(expected), reflect.ValueOf(actual)
+	if a.Kind() != reflect.Map || b.Kind() != reflect.Map || a.Type() != b.Type() || !isList(reflect.Zero(a.Type().Elem())) {
+		message := messageOrDefault(msg, "EqualUnorderedMapValues expects two maps of the same type with slice values")
+		t.Errorf("%s\nGot: %T and %T", message, expected, actual)
+		return
+	}
+
+	report := mapDiff(a, b, func(av, bv reflect.Value) bool {
+		extraA, extraB := diffLists(av, bv)
+		return len(extraA) == 0 && len(extraB) == 0
+	})
+	if report == "" {
+		return
+	}
+
+	message := messageOrDefault(msg, "map values should match")
+	t.Errorf("%s%s", message, report)
+}
+
+// mapDiff compares two maps of the same type, using equal for the values of
+// keys present in both, and describes the missing keys, extra keys and
+// differing values in key order. It returns "" if the maps are equal.
+func mapDiff(a, b reflect.Value, equal func(av, bv reflect.Value) bool) string {
+	var missing, extra, differing []string
+	for _, key := range sortedKeys(a) {
+		bv := b.MapIndex(key)
+		if !bv.IsValid() {
+			missing = append(missing, fmt.Sprintf("\n    %#v: %s", key.Interface(), formatValue(a.MapIndex(key).Interface())))
+			continue
+		}
+		av := a.MapIndex(key)
+		if !equal(av, bv) {
+			differing = append(differing, fmt.Sprintf("\n    %#v: expected %s, actual %s", key.Interface(), formatValue(av.Interface()), formatValue(bv.Interface())))
+		}
+	}
+	for _, key := range sortedKeys(b) {
+		if !a.MapIndex(key).IsValid() {
+			extra = append(extra, fmt.Sprintf("\n    %#v: %s", key.Interface(), formatValue(b.MapIndex(key).Interface())))
+		}
+	}
+
+	if len(missing) == 0 && len(extra) == 0 && len(differing) == 0 {
+		return ""
+	}
+
+	var report strings.Builder
+	for _, section := range []struct {
+		title string
+		lines []string
+	}{
+		{"Missing keys", missing},
+		{"Extra keys", extra},
+		{"Differing values", differing},
+	} {
+		if len(section.lines) > 0 {
+			fmt.Fprintf(&report, "\n%s:%s", section.title, strings.Join(section.lines, ""))
+		}
+	}
+
+	return report.String()
+}
+
+// sortedKeys returns the keys of m ordered by their printed form, so that
+// failure messages are stable.
+func sortedKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprintf("%#v", keys[i]) < fmt.Sprintf("%#v", keys[j])
+	})
+	return keys
+}
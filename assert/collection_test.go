@@ -0,0 +1,306 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestElementsMatchIgnoresOrder tests that order does not matter.
+func TestElementsMatchIgnoresOrder(t *testing.T) {
+	rec := &recordingT{}
+	ElementsMatch(rec, []int{1, 2, 3}, []int{3, 1, 2})
+
+	if rec.failed() {
+		t.Errorf("Expected no failure, got %v", rec.errors)
+	}
+}
+
+// TestElementsMatchFieldDiff tests that near-matching structs are paired with their differing fields.
+func TestElementsMatchFieldDiff(t *testing.T) {
+	expected := []*user{
+		{ID: "1", Name: "John Doe", Email: "john@example.com"},
+		{ID: "2", Name: "Jane Smith", Email: "jane@example.com"},
+	}
+	actual := []*user{
+		{ID: "2", Name: "Jane Smith", Email: "jane@example.org"},
+		{ID: "1", Name: "John Doe", Email: "john@example.com"},
+	}
+
+	rec := &recordingT{}
+	ElementsMatch(rec, expected, actual)
+
+	if !rec.failed() {
+		t.Fatal("Expected a failure for differing elements")
+	}
+
+	message := rec.errors[0]
+	if !strings.Contains(message, "A[1] and B[0] differ") {
+		t.Errorf("Expected the near-matching elements to be paired, got %q", message)
+	}
+
+	if !strings.Contains(message, `Email: "jane@example.com" != "jane@example.org"`) {
+		t.Errorf("Expected the differing field to be reported, got %q", message)
+	}
+
+	if strings.Contains(message, "Name:") {
+		t.Errorf("Expected only differing fields to be reported, got %q", message)
+	}
+}
+
+// TestElementsMatchExtraElements tests that unpaired elements are listed.
+func TestElementsMatchExtraElements(t *testing.T) {
+	rec := &recordingT{}
+	ElementsMatch(rec, []string{"a", "b"}, []string{"a", "c", "d"})
+
+	if !rec.failed() {
+		t.Fatal("Expected a failure for extra elements")
+	}
+
+	message := rec.errors[0]
+	if !strings.Contains(message, `Only in A: [1] "b"`) || !strings.Contains(message, `Only in B: [2] "d"`) {
+		t.Errorf("Expected unmatched elements to be listed, got %q", message)
+	}
+}
+
+// TestContainsValue tests searching map values.
+func TestContainsValue(t *testing.T) {
+	users := map[string]*user{
+		"1": {ID: "1", Name: "John Doe"},
+		"2": {ID: "2", Name: "Jane Smith"},
+	}
+
+	rec := &recordingT{}
+	ContainsValue(rec, users, &user{ID: "2", Name: "Jane Smith"})
+	if rec.failed() {
+		t.Errorf("Expected the user to be found, got %v", rec.errors)
+	}
+
+	rec = &recordingT{}
+	ContainsValue(rec, users, &user{ID: "3", Name: "Alice Brown"})
+	if !rec.failed() || !strings.Contains(rec.errors[0], "Alice Brown") {
+		t.Errorf("Expected a failure naming the missing value, got %v", rec.errors)
+	}
+
+	rec = &recordingT{}
+	ContainsValue(rec, []string{"a"}, "a")
+	if !rec.failed() || !strings.Contains(rec.errors[0], "expects a map") {
+		t.Errorf("Expected a failure for a non-map, got %v", rec.errors)
+	}
+}
+
+// TestIsSortedFunc tests sorting users by name with a custom comparator.
+func TestIsSortedFunc(t *testing.T) {
+	byName := func(a, b *user) bool { return a.Name < b.Name }
+
+	sorted := []*user{{Name: "Alice"}, {Name: "Bob"}, {Name: "Bob"}, {Name: "Carol"}}
+	rec := &recordingT{}
+	IsSortedFunc(rec, sorted, byName)
+	if rec.failed() {
+		t.Errorf("Expected sorted slice to pass, got %v", rec.errors)
+	}
+
+	unsorted := []*user{{Name: "Alice"}, {Name: "Carol"}, {Name: "Bob"}}
+	rec = &recordingT{}
+	IsSortedFunc(rec, unsorted, byName)
+	if !rec.failed() {
+		t.Fatal("Expected unsorted slice to fail")
+	}
+
+	if !strings.Contains(rec.errors[0], "Element [2]") || !strings.Contains(rec.errors[0], "after [1]") {
+		t.Errorf("Expected the violating pair to be reported, got %q", rec.errors[0])
+	}
+}
+
+// TestContainsOnce tests zero, one and two matching elements, and ContainsN.
+func TestContainsOnce(t *testing.T) {
+	users := []*user{{ID: "1", Name: "Alice"}, {ID: "2", Name: "Bob"}, {ID: "3", Name: "Bob"}}
+	named := func(name string) func(*user) bool {
+		return func(u *user) bool { return u.Name == name }
+	}
+
+	rec := &recordingT{}
+	ContainsOnce(rec, users, named("Alice"))
+	ContainsN(rec, users, 2, named("Bob"))
+	ContainsN(rec, users, 0, named("Carol"))
+	if rec.failed() {
+		t.Fatalf("Expected exact counts to pass, got %v", rec.errors)
+	}
+
+	ContainsOnce(rec, users, named("Carol"))
+	ContainsOnce(rec, users, named("Bob"))
+	if len(rec.errors) != 2 {
+		t.Fatalf("Expected 2 failures, got %v", rec.errors)
+	}
+	if !strings.Contains(rec.errors[0], "Expected count: 1\nActual count:   0") {
+		t.Errorf("Expected no matches to be reported, got:\n%s", rec.errors[0])
+	}
+	if !strings.Contains(rec.errors[1], "Actual count:   2\n    [1] &{ID:2 Name:Bob Email:}\n    [2] &{ID:3 Name:Bob Email:}") {
+		t.Errorf("Expected both matches to be listed, got:\n%s", rec.errors[1])
+	}
+}
+
+// TestSharesBackingArray tests distinguishing sub-slices from copies.
+func TestSharesBackingArray(t *testing.T) {
+	original := []int{1, 2, 3, 4, 5}
+	view := original[2:4]
+	duplicate := append([]int(nil), original...)
+
+	rec := &recordingT{}
+	SharesBackingArray(rec, original, view)
+	SharesBackingArray(rec, view, original[:1])
+	if rec.failed() {
+		t.Errorf("Expected sub-slices to share the backing array, got %v", rec.errors)
+	}
+
+	rec = &recordingT{}
+	SharesBackingArray(rec, original, duplicate)
+	if !rec.failed() {
+		t.Error("Expected an independent copy not to share the backing array")
+	}
+
+	rec = &recordingT{}
+	SharesBackingArray(rec, original, "not a slice")
+	if !rec.failed() || !strings.Contains(rec.errors[0], "expects two slices") {
+		t.Errorf("Expected a failure for a non-slice argument, got %v", rec.errors)
+	}
+}
+
+// TestNotAliased tests distinguishing copies from shared references.
+func TestNotAliased(t *testing.T) {
+	internal := map[string]int{"alice": 1}
+	shared := internal
+	copied := map[string]int{}
+	for k, v := range internal {
+		copied[k] = v
+	}
+	items := []string{"a", "b", "c"}
+
+	rec := &recordingT{}
+	NotAliased(rec, internal, copied)
+	NotAliased(rec, items, append([]string(nil), items...))
+	if rec.failed() {
+		t.Fatalf("Expected copies not to alias, got %v", rec.errors)
+	}
+
+	NotAliased(rec, internal, shared)
+	NotAliased(rec, items, items[1:])
+	if len(rec.errors) != 2 || !strings.Contains(rec.errors[0], "should not share storage") {
+		t.Errorf("Expected shared references to fail, got %v", rec.errors)
+	}
+
+	rec = &recordingT{}
+	NotAliased(rec, internal, items)
+	if !rec.failed() || !strings.Contains(rec.errors[0], "Got: map[string]int and []string") {
+		t.Errorf("Expected a failure for mismatched kinds, got %v", rec.errors)
+	}
+}
+
+// TestLen tests length checks across supported kinds.
+func TestLen(t *testing.T) {
+	rec := &recordingT{}
+	Len(rec, []int{1, 2, 3}, 3)
+	Len(rec, "abc", 3)
+	Len(rec, map[string]int{"a": 1}, 1)
+	Len(rec, [2]bool{}, 2)
+	if rec.failed() {
+		t.Errorf("Expected no failure, got %v", rec.errors)
+	}
+
+	rec = &recordingT{}
+	Len(rec, []int{1, 2}, 3)
+	if !rec.failed() || !strings.Contains(rec.errors[0], "Actual length:   2") {
+		t.Errorf("Expected a length mismatch failure, got %v", rec.errors)
+	}
+}
+
+// TestLenUnsupportedKind tests the message for values without a length.
+func TestLenUnsupportedKind(t *testing.T) {
+	rec := &recordingT{}
+	Len(rec, 42, 0)
+
+	if !rec.failed() || !strings.Contains(rec.errors[0], "object of type int does not have a length") {
+		t.Errorf("Expected a descriptive failure for an int, got %v", rec.errors)
+	}
+
+	rec = &recordingT{}
+	Len(rec, nil, 0)
+	if !rec.failed() || !strings.Contains(rec.errors[0], "object of type <nil> does not have a length") {
+		t.Errorf("Expected a descriptive failure for nil, got %v", rec.errors)
+	}
+}
+
+// TestLenNegativeExpected tests that a negative expected length is rejected.
+func TestLenNegativeExpected(t *testing.T) {
+	rec := &recordingT{}
+	Len(rec, []int{}, -1)
+
+	if !rec.failed() || !strings.Contains(rec.errors[0], "invalid expected length -1") {
+		t.Errorf("Expected a programmer-error failure, got %v", rec.errors)
+	}
+}
+
+// TestMapEqualReportsKeys tests a differing value and an extra key.
+func TestMapEqualReportsKeys(t *testing.T) {
+	expected := map[string]int{"alice": 1, "bob": 2, "carol": 3}
+	actual := map[string]int{"alice": 1, "bob": 20, "carol": 3, "dave": 4}
+
+	rec := &recordingT{}
+	MapEqual(rec, expected, expected)
+	if rec.failed() {
+		t.Errorf("Expected equal maps to pass, got %v", rec.errors)
+	}
+
+	MapEqual(rec, expected, actual)
+	if len(rec.errors) != 1 {
+		t.Fatalf("Expected 1 failure, got %v", rec.errors)
+	}
+
+	want := "maps should be equal\nExtra keys:\n    \"dave\": 4\nDiffering values:\n    \"bob\": expected 2, actual 20"
+	if rec.errors[0] != want {
+		t.Errorf("Unexpected failure message:\n%s\nwant:\n%s", rec.errors[0], want)
+	}
+}
+
+// TestEqualUnorderedMapValues tests maps of slices differing only in the
+// order within each key, and the key reported when a group differs.
+func TestEqualUnorderedMapValues(t *testing.T) {
+	expected := map[string][]string{"admins": {"alice", "bob"}, "users": {"carol", "dave", "erin"}}
+	actual := map[string][]string{"admins": {"bob", "alice"}, "users": {"erin", "carol", "dave"}}
+
+	rec := &recordingT{}
+	EqualUnorderedMapValues(rec, expected, actual)
+	if rec.failed() {
+		t.Fatalf("Expected maps differing only in order to pass, got %v", rec.errors)
+	}
+
+	actual["users"] = []string{"carol", "dave", "dave"}
+	EqualUnorderedMapValues(rec, expected, actual)
+	EqualUnorderedMapValues(rec, map[string]int{}, map[string]int{})
+	if len(rec.errors) != 2 {
+		t.Fatalf("Expected 2 failures, got %v", rec.errors)
+	}
+	want := "map values should match\nDiffering values:\n    \"users\": expected [carol dave erin], actual [carol dave dave]"
+	if rec.errors[0] != want {
+		t.Errorf("Unexpected failure message:\n%s\nwant:\n%s", rec.errors[0], want)
+	}
+	if !strings.Contains(rec.errors[1], "with slice values") {
+		t.Errorf("Expected non-slice values to be rejected, got:\n%s", rec.errors[1])
+	}
+}
+
+// TestMapEqualMissingKeyAndInvalidInput tests missing keys and non-map input.
+func TestMapEqualMissingKeyAndInvalidInput(t *testing.T) {
+	rec := &recordingT{}
+	MapEqual(rec, map[string]int{"alice": 1}, map[string]int{})
+	MapEqual(rec, map[string]int{}, []int{})
+
+	if len(rec.errors) != 2 {
+		t.Fatalf("Expected 2 failures, got %v", rec.errors)
+	}
+	if !strings.Contains(rec.errors[0], "Missing keys:\n    \"alice\": 1") {
+		t.Errorf("Expected the missing key to be listed, got:\n%s", rec.errors[0])
+	}
+	if !strings.Contains(rec.errors[1], "Got: map[string]int and []int") {
+		t.Errorf("Expected the invalid types to be reported, got:\n%s", rec.errors[1])
+	}
+}
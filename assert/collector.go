@@ -0,0 +1,73 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Collector is a TestingT that buffers assertion failures instead of
+// reporting them immediately, enabling a "soft assertion" workflow:
+//
+//	c := assert.NewCollector(t)
+//	assert.Equal(c, "John", user.Name)
+//	assert.NotNil(c, user.Email)
+//
+// Every assertion in this package accepts a Collector. Buffered failures are
+// reported together by Flush, which runs automatically at the end of the test
+// when t supports Cleanup.
+type Collector struct {
+	t        TestingT
+	mu       sync.Mutex
+	failures []string
+}
+
+// NewCollector creates a Collector reporting to t.
+func NewCollector(t TestingT) *Collector {
+	c := &Collector{t: t}
+	if ct, ok := t.(interface{ Cleanup(func()) }); ok {
+		ct.Cleanup(c.Flush)
+	}
+	return c
+}
+
+// Helper marks the calling function as a test helper function.
+func (c *Collector) Helper() {
+	c.t.Helper()
+}
+
+// Errorf buffers a failure message.
+func (c *Collector) Errorf(format string, args ...any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failures = append(c.failures, fmt.Sprintf(format, args...))
+}
+
+// Failures returns the failure messages buffered so far.
+func (c *Collector) Failures() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.failures...)
+}
+
+// Flush reports all buffered failures to the underlying test as a single
+// failure and clears the buffer. It does nothing if no failure was buffered.
+func (c *Collector) Flush() {
+	c.t.Helper()
+
+	c.mu.Lock()
+	failures := c.failures
+	c.failures = nil
+	c.mu.Unlock()
+
+	if len(failures) == 0 {
+		return
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%d assertion(s) failed:", len(failures))
+	for i, failure := range failures {
+		fmt.Fprintf(&buf, "\n[%d] %s", i+1, strings.ReplaceAll(failure, "\n", "\n    "))
+	}
+	c.t.Errorf("%s", buf.String())
+}
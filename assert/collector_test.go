@@ -0,0 +1,72 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+// cleanupT is a recordingT that also stores cleanup functions.
+type cleanupT struct {
+	recordingT
+	cleanups []func()
+}
+
+func (c *cleanupT) Cleanup(fn func()) {
+	c.cleanups = append(c.cleanups, fn)
+}
+
+// TestCollectorBuffersFailures tests that all failures surface together on Flush.
+func TestCollectorBuffersFailures(t *testing.T) {
+	rec := &recordingT{}
+	c := NewCollector(rec)
+
+	Equal(c, "John Doe", "Jane Doe", "name should match")
+	True(c, false, "user should be active")
+	Equal(c, 1, 1)
+	NoError(c, nil)
+	Equal(c, "john@example.com", "jane@example.com", "email should match")
+
+	if rec.failed() {
+		t.Fatalf("Expected no failure before Flush, got %v", rec.errors)
+	}
+
+	if got := len(c.Failures()); got != 3 {
+		t.Fatalf("Expected 3 buffered failures, got %d", got)
+	}
+
+	c.Flush()
+
+	if len(rec.errors) != 1 {
+		t.Fatalf("Expected a single combined failure, got %v", rec.errors)
+	}
+
+	message := rec.errors[0]
+	for _, part := range []string{"3 assertion(s) failed", "[1] name should match", "[2] user should be active", "[3] email should match"} {
+		if !strings.Contains(message, part) {
+			t.Errorf("Expected combined failure to contain %q, got %q", part, message)
+		}
+	}
+
+	c.Flush()
+	if len(rec.errors) != 1 {
+		t.Errorf("Expected a second Flush to report nothing, got %v", rec.errors)
+	}
+}
+
+// TestCollectorFlushesOnCleanup tests that failures are reported automatically at cleanup.
+func TestCollectorFlushesOnCleanup(t *testing.T) {
+	ct := &cleanupT{}
+	c := NewCollector(ct)
+
+	False(c, true)
+
+	if len(ct.cleanups) != 1 {
+		t.Fatalf("Expected Flush to be registered as cleanup, got %d cleanups", len(ct.cleanups))
+	}
+
+	ct.cleanups[0]()
+
+	if !ct.failed() || !strings.Contains(ct.errors[0], "expected false but got true") {
+		t.Errorf("Expected buffered failure to be reported at cleanup, got %v", ct.errors)
+	}
+}
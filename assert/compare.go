@@ -0,0 +1,96 @@
+package assert
+
+import (
+	"reflect"
+	"time"
+)
+
+// Greater asserts that e1 is greater than e2. Both values must have the same
+// ordered type: an integer, float, string, time.Time or time.Duration.
+func Greater(t TestingT, e1, e2 any, msg ...string) {
+	t.Helper()
+	assertOrder(t, e1, e2, func(c int) bool { return c > 0 }, "greater than", msg)
+}
+
+// GreaterOrEqual asserts that e1 is greater than or equal to e2.
+func GreaterOrEqual(t TestingT, e1, e2 any, msg ...string) {
+	t.Helper()
+	assertOrder(t, e1, e2, func(c int) bool { return c >= 0 }, "greater than or equal to", msg)
+}
+
+// Less asserts that e1 is less than e2.
+func Less(t TestingT, e1, e2 any, msg ...string) {
+	t.Helper()
+	assertOrder(t, e1, e2, func(c int) bool { return c < 0 }, "less than", msg)
+}
+
+// LessOrEqual asserts that e1 is less than or equal to e2.
+func LessOrEqual(t TestingT, e1, e2 any, msg ...string) {
+	t.Helper()
+	assertOrder(t, e1, e2, func(c int) bool { return c <= 0 }, "less than or equal to", msg)
+}
+
+// assertOrder compares e1 with e2 and fails unless ok accepts the result.
+func assertOrder(t TestingT, e1, e2 any, ok func(int) bool, relation string, msg []string) {
+	t.Helper()
+
+	c, comparable := compareOrdered(e1, e2)
+	if !comparable {
+		message := messageOrDefault(msg, "values are not comparable")
+		t.Errorf("%s\nCannot compare %T with %T", message, e1, e2)
+		return
+	}
+
+	if !ok(c) {
+		message := messageOrDefault(msg, "unexpected ordering")
+		t.Errorf("%s\nExpected %s to be %s %s", message, formatValue(e1), relation, formatValue(e2))
+	}
+}
+
+// compareOrdered returns -1, 0 or +1 depending on whether a is less than,
+// equal to or greater than b. It reports false if the values are of
+// different types or their type has no natural ordering. time.Time values
+// are compared by instant.
+func compareOrdered(a, b any) (int, bool) {
+	if ta, ok := a.(time.Time); ok {
+		tb, ok := b.(time.Time)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case ta.Before(tb):
+			return -1, true
+		case ta.After(tb):
+			return 1, true
+		}
+		return 0, true
+	}
+
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if !va.IsValid() || !vb.IsValid() || va.Type() != vb.Type() {
+		return 0, false
+	}
+
+	switch va.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compare(va.Int(), vb.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return compare(va.Uint(), vb.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return compare(va.Float(), vb.Float()), true
+	case reflect.String:
+		return compare(va.String(), vb.String()), true
+	}
+	return 0, false
+}
+
+// compare orders two values of an ordered type.
+func compare[T int64 | uint64 | float64 | string](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
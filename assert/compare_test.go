@@ -0,0 +1,75 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestOrderedNumbers tests the ordered assertions with numbers and strings.
+func TestOrderedNumbers(t *testing.T) {
+	rec := &recordingT{}
+	Greater(rec, 2, 1)
+	GreaterOrEqual(rec, 2, 2)
+	Less(rec, 1.5, 2.5)
+	LessOrEqual(rec, "a", "b")
+
+	if rec.failed() {
+		t.Errorf("Expected no failure, got %v", rec.errors)
+	}
+
+	rec = &recordingT{}
+	Greater(rec, 1, 2)
+	if !rec.failed() || !strings.Contains(rec.errors[0], "Expected 1 to be greater than 2") {
+		t.Errorf("Expected an ordering failure, got %v", rec.errors)
+	}
+}
+
+// TestOrderedTimes tests that times are compared by instant.
+func TestOrderedTimes(t *testing.T) {
+	earlier := time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour).In(time.FixedZone("CET", 60*60))
+
+	rec := &recordingT{}
+	Greater(rec, later, earlier)
+	Less(rec, earlier, later)
+	GreaterOrEqual(rec, earlier, earlier.In(time.FixedZone("CET", 60*60)))
+	LessOrEqual(rec, earlier, later)
+
+	if rec.failed() {
+		t.Errorf("Expected no failure, got %v", rec.errors)
+	}
+
+	rec = &recordingT{}
+	Less(rec, later, earlier)
+	if !rec.failed() || !strings.Contains(rec.errors[0], "2024-03-10 14:00:00 +0100 CET to be less than 2024-03-10 12:00:00 +0000 UTC") {
+		t.Errorf("Expected a readable time failure, got %v", rec.errors)
+	}
+}
+
+// TestOrderedDurations tests that durations compare numerically with readable output.
+func TestOrderedDurations(t *testing.T) {
+	rec := &recordingT{}
+	Greater(rec, 2*time.Second, 1500*time.Millisecond)
+	LessOrEqual(rec, time.Minute, 60*time.Second)
+
+	if rec.failed() {
+		t.Errorf("Expected no failure, got %v", rec.errors)
+	}
+
+	rec = &recordingT{}
+	Less(rec, 2*time.Second, time.Second)
+	if !rec.failed() || !strings.Contains(rec.errors[0], "Expected 2s to be less than 1s") {
+		t.Errorf("Expected a readable duration failure, got %v", rec.errors)
+	}
+}
+
+// TestOrderedMismatchedTypes tests that different types are reported.
+func TestOrderedMismatchedTypes(t *testing.T) {
+	rec := &recordingT{}
+	Greater(rec, time.Second, 1)
+
+	if !rec.failed() || !strings.Contains(rec.errors[0], "Cannot compare time.Duration with int") {
+		t.Errorf("Expected a type mismatch failure, got %v", rec.errors)
+	}
+}
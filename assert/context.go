@@ -0,0 +1,31 @@
+package assert
+
+import (
+	"context"
+	"errors"
+)
+
+// ContextCancelled asserts that ctx is done, that is ctx.Err() is non-nil
+// because it was cancelled or its deadline passed.
+func ContextCancelled(t TestingT, ctx context.Context, msg ...string) {
+	t.Helper()
+
+	if ctx.Err() == nil {
+		message := messageOrDefault(msg, "context should be cancelled")
+		t.Errorf(message)
+	}
+}
+
+// RespectsCancellation calls fn with an already-cancelled context and asserts
+// that it returns context.Canceled, or an error wrapping it.
+func RespectsCancellation(t TestingT, fn func(context.Context) error, msg ...string) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := fn(ctx); !errors.Is(err, context.Canceled) {
+		message := messageOrDefault(msg, "function should return context.Canceled for a cancelled context")
+		t.Errorf("%s\n%s", message, describeError(err))
+	}
+}
@@ -0,0 +1,48 @@
+package assert
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestContextCancelled tests live and cancelled contexts.
+func TestContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	rec := &recordingT{}
+	ContextCancelled(rec, ctx)
+	if !rec.failed() {
+		t.Error("Expected a live context to fail")
+	}
+
+	cancel()
+	ContextCancelled(rec, ctx)
+	if len(rec.errors) != 1 {
+		t.Errorf("Expected a cancelled context to pass, got %v", rec.errors)
+	}
+}
+
+// TestRespectsCancellation tests functions that do and do not check ctx.Err().
+func TestRespectsCancellation(t *testing.T) {
+	rec := &recordingT{}
+	RespectsCancellation(rec, func(ctx context.Context) error {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("sync aborted: %w", err)
+		}
+		return nil
+	})
+	if rec.failed() {
+		t.Fatalf("Expected a function checking ctx.Err() to pass, got %v", rec.errors)
+	}
+
+	RespectsCancellation(rec, func(ctx context.Context) error {
+		time.Sleep(time.Millisecond)
+		return nil
+	})
+	if len(rec.errors) != 1 || !strings.Contains(rec.errors[0], "Got: <nil>") {
+		t.Errorf("Expected a function ignoring the context to fail, got %v", rec.errors)
+	}
+}
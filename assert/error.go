@@ -0,0 +1,140 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// NoError asserts that err is nil. On failure the message lists every layer of
+// the error chain, obtained through errors.Unwrap, and the innermost error type.
+func NoError(t TestingT, err error, msg ...string) {
+	t.Helper()
+
+	if err != nil {
+		message := messageOrDefault(msg, "expected no error")
+		t.Errorf("%s\n%s", message, errorChain(err))
+	}
+}
+
+// Error asserts that err is not nil.
+func Error(t TestingT, err error, msg ...string) {
+	t.Helper()
+
+	if err == nil {
+		message := messageOrDefault(msg, "expected an error")
+		t.Errorf("%s\nGot: <nil>", message)
+	}
+}
+
+// ErrorIs asserts that target is found in err's chain, as reported by errors.Is.
+func ErrorIs(t TestingT, err, target error, msg ...string) {
+	t.Helper()
+
+	if !errors.Is(err, target) {
+		message := messageOrDefault(msg, "error chain does not contain target")
+		t.Errorf("%s\nTarget: %v\n%s", message, target, describeError(err))
+	}
+}
+
+// ErrorContains asserts that err is not nil and its message contains substr.
+func ErrorContains(t TestingT, err error, substr string, msg ...string) {
+	t.Helper()
+
+	if err == nil || !strings.Contains(err.Error(), substr) {
+		message := messageOrDefault(msg, "error message does not contain substring")
+		t.Errorf("%s\nSubstring: %q\n%s", message, substr, describeError(err))
+	}
+}
+
+// JoinedErrorContains asserts that one of the errors joined into err, for
+// example with errors.Join, matches target according to errors.Is. Nested
+// joins are walked through their Unwrap() []error method, and an error that
+// is not joined is treated as a join of one. On failure every joined error is
+// listed.
+func JoinedErrorContains(t TestingT, err, target error, msg ...string) {
+	t.Helper()
+
+	joined := joinedErrors(err)
+	for _, e := range joined {
+		if errors.Is(e, target) {
+			return
+		}
+	}
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "Target: %v\nJoined errors (%d):", target, len(joined))
+	for i, e := range joined {
+		fmt.Fprintf(&buf, "\n  [%d] %T: %v", i, e, e)
+	}
+
+	message := messageOrDefault(msg, "joined error does not contain target")
+	t.Errorf("%s\n%s", message, buf.String())
+}
+
+// joinedErrors flattens err into the errors joined into it.
+func joinedErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+
+	multi, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
+	}
+
+	var flat []error
+	for _, e := range multi.Unwrap() {
+		flat = append(flat, joinedErrors(e)...)
+	}
+	return flat
+}
+
+// Errorf is like Error but builds the failure message from format and args.
+func Errorf(t TestingT, err error, format string, args ...any) {
+	t.Helper()
+	Error(t, err, fmt.Sprintf(format, args...))
+}
+
+// NoErrorf is like NoError but builds the failure message from format and args.
+func NoErrorf(t TestingT, err error, format string, args ...any) {
+	t.Helper()
+	NoError(t, err, fmt.Sprintf(format, args...))
+}
+
+// ErrorIsf is like ErrorIs but builds the failure message from format and args.
+func ErrorIsf(t TestingT, err, target error, format string, args ...any) {
+	t.Helper()
+	ErrorIs(t, err, target, fmt.Sprintf(format, args...))
+}
+
+// ErrorContainsf is like ErrorContains but builds the failure message from
+// format and args.
+func ErrorContainsf(t TestingT, err error, substr string, format string, args ...any) {
+	t.Helper()
+	ErrorContains(t, err, substr, fmt.Sprintf(format, args...))
+}
+
+// describeError renders err's chain, or notes that there was no error.
+func describeError(err error) string {
+	if err == nil {
+		return "Got: <nil>"
+	}
+	return errorChain(err)
+}
+
+// errorChain renders each layer of err's wrap chain on its own line.
+func errorChain(err error) string {
+	var buf strings.Builder
+	buf.WriteString("Error chain:")
+
+	innermost := err
+	for i := 0; err != nil; i++ {
+		fmt.Fprintf(&buf, "\n  [%d] %T: %v", i, err, err)
+		innermost = err
+		err = errors.Unwrap(err)
+	}
+
+	fmt.Fprintf(&buf, "\nInnermost type: %T", innermost)
+	return buf.String()
+}
@@ -0,0 +1,210 @@
+package assert
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestNoErrorPasses tests that a nil error passes.
+func TestNoErrorPasses(t *testing.T) {
+	rec := &recordingT{}
+	NoError(rec, nil)
+
+	if rec.failed() {
+		t.Errorf("Expected no failure, got %v", rec.errors)
+	}
+}
+
+// TestNoErrorPrintsChain tests that every wrapped layer appears in the failure.
+func TestNoErrorPrintsChain(t *testing.T) {
+	root := &validationError{Field: "email"}
+	err := fmt.Errorf("service: %w", fmt.Errorf("repository: %w", fmt.Errorf("query: %w", root)))
+
+	rec := &recordingT{}
+	NoError(rec, err)
+
+	if !rec.failed() {
+		t.Fatal("Expected a failure for a non-nil error")
+	}
+
+	message := rec.errors[0]
+	layers := []string{
+		"[0] *fmt.wrapError: service: repository: query: invalid email",
+		"[1] *fmt.wrapError: repository: query: invalid email",
+		"[2] *fmt.wrapError: query: invalid email",
+		"[3] *assert.validationError: invalid email",
+		"Innermost type: *assert.validationError",
+	}
+	for _, layer := range layers {
+		if !strings.Contains(message, layer) {
+			t.Errorf("Expected failure to contain %q, got %q", layer, message)
+		}
+	}
+}
+
+// TestNoErrorUnwrappedError tests the chain of a plain error.
+func TestNoErrorUnwrappedError(t *testing.T) {
+	rec := &recordingT{}
+	NoError(rec, errors.New("boom"), "save should succeed")
+
+	if !rec.failed() || !strings.HasPrefix(rec.errors[0], "save should succeed") {
+		t.Fatalf("Expected failure with custom message, got %v", rec.errors)
+	}
+
+	if !strings.Contains(rec.errors[0], "Innermost type: *errors.errorString") {
+		t.Errorf("Expected innermost type, got %q", rec.errors[0])
+	}
+}
+
+// TestEqualComparesErrorsByMessage tests that distinct errors with the same message are equal.
+func TestEqualComparesErrorsByMessage(t *testing.T) {
+	rec := &recordingT{}
+	Equal(rec, errors.New("id cannot be empty"), errors.New("id cannot be empty"))
+
+	if rec.failed() {
+		t.Errorf("Expected errors with the same message to be equal, got %v", rec.errors)
+	}
+
+	rec = &recordingT{}
+	Equal(rec, errors.New("id cannot be empty"), errors.New("name cannot be empty"))
+
+	if !rec.failed() {
+		t.Fatal("Expected errors with different messages to fail")
+	}
+
+	if !strings.Contains(rec.errors[0], "compared by their Error() message") {
+		t.Errorf("Expected the message-based comparison to be noted, got %q", rec.errors[0])
+	}
+}
+
+type codeError struct {
+	code int
+}
+
+func (e *codeError) Error() string {
+	return fmt.Sprintf("code %d", e.code)
+}
+
+// TestEqualTypedNilErrors tests that typed nil error pointers are compared
+// without calling their Error method.
+func TestEqualTypedNilErrors(t *testing.T) {
+	rec := &recordingT{}
+	Equal(rec, (*codeError)(nil), (*codeError)(nil))
+	NotEqual(rec, (*codeError)(nil), &codeError{code: 1})
+	NotEqual(rec, &codeError{code: 1}, (*codeError)(nil))
+	if rec.failed() {
+		t.Fatalf("Expected typed nil errors to compare without panicking, got %v", rec.errors)
+	}
+
+	Equal(rec, (*codeError)(nil), &codeError{code: 1})
+	if len(rec.errors) != 1 {
+		t.Errorf("Expected a typed nil and a non-nil error to differ, got %v", rec.errors)
+	}
+}
+
+// TestErrorf tests that a nil error fails with the formatted message.
+func TestErrorf(t *testing.T) {
+	rec := &recordingT{}
+	Errorf(rec, errors.New("boom"), "operation %s should fail", "delete")
+	if rec.failed() {
+		t.Errorf("Expected no failure for a non-nil error, got %v", rec.errors)
+	}
+
+	Errorf(rec, nil, "operation %s should fail", "delete")
+	if !rec.failed() || !strings.HasPrefix(rec.errors[0], "operation delete should fail\n") {
+		t.Errorf("Expected formatted failure, got %v", rec.errors)
+	}
+}
+
+// TestNoErrorf tests that a non-nil error fails with the formatted message and chain.
+func TestNoErrorf(t *testing.T) {
+	rec := &recordingT{}
+	NoErrorf(rec, nil, "saving user %d", 42)
+	if rec.failed() {
+		t.Errorf("Expected no failure for a nil error, got %v", rec.errors)
+	}
+
+	NoErrorf(rec, &validationError{Field: "email"}, "saving user %d", 42)
+	if !rec.failed() {
+		t.Fatal("Expected a failure for a non-nil error")
+	}
+	for _, want := range []string{"saving user 42", "*assert.validationError: invalid email"} {
+		if !strings.Contains(rec.errors[0], want) {
+			t.Errorf("Expected failure to contain %q, got:\n%s", want, rec.errors[0])
+		}
+	}
+}
+
+// TestErrorIsf tests matching through a wrap chain and the formatted failure.
+func TestErrorIsf(t *testing.T) {
+	errNotFound := errors.New("not found")
+
+	rec := &recordingT{}
+	ErrorIsf(rec, fmt.Errorf("find user: %w", errNotFound), errNotFound, "lookup of %q", "alice")
+	if rec.failed() {
+		t.Errorf("Expected wrapped target to match, got %v", rec.errors)
+	}
+
+	ErrorIsf(rec, errors.New("timeout"), errNotFound, "lookup of %q", "alice")
+	if !rec.failed() {
+		t.Fatal("Expected a failure for an unrelated error")
+	}
+	for _, want := range []string{`lookup of "alice"`, "Target: not found", "*errors.errorString: timeout"} {
+		if !strings.Contains(rec.errors[0], want) {
+			t.Errorf("Expected failure to contain %q, got:\n%s", want, rec.errors[0])
+		}
+	}
+}
+
+// TestErrorContainsf tests substring matching, nil errors and the formatted failure.
+func TestErrorContainsf(t *testing.T) {
+	rec := &recordingT{}
+	ErrorContainsf(rec, errors.New("invalid email address"), "email", "input %d", 1)
+	if rec.failed() {
+		t.Errorf("Expected substring to match, got %v", rec.errors)
+	}
+
+	ErrorContainsf(rec, errors.New("invalid name"), "email", "input %d", 2)
+	ErrorContainsf(rec, nil, "email", "input %d", 3)
+	if len(rec.errors) != 2 {
+		t.Fatalf("Expected 2 failures, got %v", rec.errors)
+	}
+	if !strings.Contains(rec.errors[0], "input 2") || !strings.Contains(rec.errors[0], `Substring: "email"`) {
+		t.Errorf("Unexpected failure message:\n%s", rec.errors[0])
+	}
+	if !strings.Contains(rec.errors[1], "input 3") || !strings.Contains(rec.errors[1], "Got: <nil>") {
+		t.Errorf("Unexpected failure message:\n%s", rec.errors[1])
+	}
+}
+
+// TestJoinedErrorContains tests finding a target among joined errors.
+func TestJoinedErrorContains(t *testing.T) {
+	errNotFound := errors.New("not found")
+	err := errors.Join(
+		&validationError{Field: "email"},
+		errors.Join(errors.New("timeout"), fmt.Errorf("user 7: %w", errNotFound)),
+	)
+
+	rec := &recordingT{}
+	JoinedErrorContains(rec, err, errNotFound)
+	if rec.failed() {
+		t.Errorf("Expected the wrapped target to be found, got %v", rec.errors)
+	}
+
+	JoinedErrorContains(rec, err, errors.New("conflict"))
+	if len(rec.errors) != 1 {
+		t.Fatalf("Expected 1 failure, got %v", rec.errors)
+	}
+	for _, want := range []string{
+		"Joined errors (3):",
+		"[0] *assert.validationError: invalid email",
+		"[1] *errors.errorString: timeout",
+		"[2] *fmt.wrapError: user 7: not found",
+	} {
+		if !strings.Contains(rec.errors[0], want) {
+			t.Errorf("Expected failure to contain %q, got:\n%s", want, rec.errors[0])
+		}
+	}
+}
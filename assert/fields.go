@@ -0,0 +1,183 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Fields asserts that each named field of the struct obj, or of the struct
+// it points to, satisfies its constraint. Every failing field is reported,
+// and names that match no field are listed separately so that a typo in a
+// key is not mistaken for a failing constraint.
+func Fields(t TestingT, obj any, constraints map[string]func(any) bool, msg ...string) {
+	t.Helper()
+
+	v := indirect(reflect.ValueOf(obj))
+	if !v.IsValid() || v.Kind() != reflect.Struct {
+		message := messageOrDefault(msg, "Fields expects a struct or a pointer to a struct")
+		t.Errorf("%s\nGot: %T", message, obj)
+		return
+	}
+
+	names := make([]string, 0, len(constraints))
+	for name := range constraints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var failing, missing []string
+	for _, name := range names {
+		field, ok := v.Type().FieldByName(name)
+		if !ok || !field.IsExported() {
+			missing = append(missing, name)
+			continue
+		}
+		value := v.FieldByIndex(field.Index).Interface()
+		if !constraints[name](value) {
+			failing = append(failing, fmt.Sprintf("\n    %s: %s", name, formatValue(value)))
+		}
+	}
+
+	if len(failing) == 0 && len(missing) == 0 {
+		return
+	}
+
+	var report strings.Builder
+	if len(failing) > 0 {
+		fmt.Fprintf(&report, "\nFailing fields:%s", strings.Join(failing, ""))
+	}
+	if len(missing) > 0 {
+		fmt.Fprintf(&report, "\nUnknown fields of %s: %s", v.Type(), strings.Join(missing, ", "))
+	}
+
+	message := messageOrDefault(msg, "field constraints not satisfied")
+	t.Errorf("%s%s", message, report.String())
+}
+
+// EqualIgnoring asserts that two structs, or pointers to structs, are equal
+// once the fields named in ignoreFields are zeroed in both. Nested fields are
+// named by dotted path, such as "Meta.CreatedAt", following pointers. The
+// arguments are not modified. The failure lists every other field that
+// differs; names that match no exported field are reported as well.
+func EqualIgnoring(t TestingT, expected, actual any, ignoreFields []string, msg ...string) {
+	t.Helper()
+
+	exp, act := reflect.ValueOf(expected), reflect.ValueOf(actual)
+	if !exp.IsValid() || !act.IsValid() || exp.Type() != act.Type() || indirect(exp).Kind() != reflect.Struct {
+		message := messageOrDefault(msg, "EqualIgnoring expects two structs or pointers to structs of the same type")
+		t.Errorf("%s\nGot: %T and %T", message, expected, actual)
+		return
+	}
+
+	expCopy, actCopy := cloneValue(exp), cloneValue(act)
+	var unknown []string
+	for _, path := range ignoreFields {
+		okExp := zeroPath(expCopy, strings.Split(path, "."))
+		okAct := zeroPath(actCopy, strings.Split(path, "."))
+		if !okExp && !okAct {
+			unknown = append(unknown, path)
+		}
+	}
+
+	if len(unknown) > 0 {
+		message := messageOrDefault(msg, "EqualIgnoring was given unknown fields")
+		t.Errorf("%s\nUnknown fields of %s: %s", message, indirect(exp).Type(), strings.Join(unknown, ", "))
+		return
+	}
+
+	if !objectsAreEqual(expCopy.Interface(), actCopy.Interface()) {
+		message := messageOrDefault(msg, "values should be equal apart from ignored fields")
+		diffs := differingFields(indirect(expCopy), indirect(actCopy), "")
+		t.Errorf("%s\nIgnored: %s\nDiffering fields:\n    %s", message, strings.Join(ignoreFields, ", "), strings.Join(diffs, "\n    "))
+	}
+}
+
+// cloneValue returns an addressable copy of v. Pointers are followed and
+// their target copied too, so fields of the copy can be zeroed safely.
+func cloneValue(v reflect.Value) reflect.Value {
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	if c.Kind() == reflect.Ptr && !c.IsNil() {
+		target := reflect.New(c.Type().Elem())
+		target.Elem().Set(c.Elem())
+		c.Set(target)
+	}
+	return c
+}
+
+// zeroPath zeroes the field at path inside the addressable value v, copying
+// any pointer it passes through first so that shared data is left alone. It
+// reports whether path names an exported field. A nil pointer along the path
+// counts as already zero.
+func zeroPath(v reflect.Value, path []string) bool {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return fieldPathExists(v.Type().Elem(), path)
+		}
+		v.Set(cloneValue(v))
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+
+	field, ok := v.Type().FieldByName(path[0])
+	if !ok || !field.IsExported() {
+		return false
+	}
+	fv := v.FieldByIndex(field.Index)
+	if len(path) == 1 {
+		fv.Set(reflect.Zero(fv.Type()))
+		return true
+	}
+	return zeroPath(fv, path[1:])
+}
+
+// fieldPathExists reports whether path names an exported field of typ.
+func fieldPathExists(typ reflect.Type, path []string) bool {
+	for _, name := range path {
+		if typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		if typ.Kind() != reflect.Struct {
+			return false
+		}
+		field, ok := typ.FieldByName(name)
+		if !ok || !field.IsExported() {
+			return false
+		}
+		typ = field.Type
+	}
+	return true
+}
+
+// differingFields lists the dotted paths of the exported leaf fields that
+// differ between two values of the same struct type.
+func differingFields(a, b reflect.Value, prefix string) []string {
+	var diffs []string
+	for i := 0; i < a.NumField(); i++ {
+		field := a.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		path := prefix + field.Name
+		av, bv := a.Field(i), b.Field(i)
+		if objectsAreEqual(av.Interface(), bv.Interface()) {
+			continue
+		}
+		if ia, ib := indirect(av), indirect(bv); ia.IsValid() && ib.IsValid() && ia.Kind() == reflect.Struct && ia.Type() == ib.Type() {
+			if nested := differingFields(ia, ib, path+"."); len(nested) > 0 {
+				diffs = append(diffs, nested...)
+				continue
+			}
+		}
+		diffs = append(diffs, fmt.Sprintf("%s: expected %s, actual %s", path, formatValue(av.Interface()), formatValue(bv.Interface())))
+	}
+	if len(diffs) == 0 {
+		diffs = append(diffs, "(unexported fields differ)")
+	}
+	return diffs
+}
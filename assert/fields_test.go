@@ -0,0 +1,86 @@
+package assert
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestFields tests one passing and one failing field constraint.
+func TestFields(t *testing.T) {
+	emailPattern := regexp.MustCompile(`^[^@]+@[^@]+\.[a-z]+$`)
+	constraints := map[string]func(any) bool{
+		"Name":  func(v any) bool { return v.(string) != "" },
+		"Email": func(v any) bool { return emailPattern.MatchString(v.(string)) },
+	}
+
+	rec := &recordingT{}
+	Fields(rec, &user{ID: "1", Name: "Alice", Email: "alice@example.com"}, constraints)
+	if rec.failed() {
+		t.Errorf("Expected a valid user to pass, got %v", rec.errors)
+	}
+
+	Fields(rec, user{ID: "2", Name: "Bob", Email: "not-an-email"}, constraints)
+	if len(rec.errors) != 1 {
+		t.Fatalf("Expected 1 failure, got %v", rec.errors)
+	}
+	if !strings.Contains(rec.errors[0], "Failing fields:\n    Email: not-an-email") || strings.Contains(rec.errors[0], "Name:") {
+		t.Errorf("Expected only Email to be reported, got:\n%s", rec.errors[0])
+	}
+}
+
+// TestFieldsUnknownField tests that misspelled field names are reported distinctly.
+func TestFieldsUnknownField(t *testing.T) {
+	rec := &recordingT{}
+	Fields(rec, user{Name: "Alice"}, map[string]func(any) bool{
+		"Name":  func(v any) bool { return v != "" },
+		"Emial": func(v any) bool { return true },
+	})
+
+	if len(rec.errors) != 1 {
+		t.Fatalf("Expected 1 failure, got %v", rec.errors)
+	}
+	if !strings.Contains(rec.errors[0], "Unknown fields of assert.user: Emial") || strings.Contains(rec.errors[0], "Failing fields") {
+		t.Errorf("Expected only the unknown field to be reported, got:\n%s", rec.errors[0])
+	}
+}
+
+type auditInfo struct {
+	CreatedAt time.Time
+	Author    string
+}
+
+type document struct {
+	ID    string
+	Title string
+	Meta  *auditInfo
+}
+
+// TestEqualIgnoring tests values differing only in ignored fields, and a
+// failure naming the non-ignored field that differs.
+func TestEqualIgnoring(t *testing.T) {
+	rec := &recordingT{}
+	EqualIgnoring(rec, user{ID: "1", Name: "Alice"}, user{ID: "42", Name: "Alice"}, []string{"ID"})
+	if rec.failed() {
+		t.Fatalf("Expected users differing only in ID to pass, got %v", rec.errors)
+	}
+
+	expected := &document{ID: "a", Title: "Draft", Meta: &auditInfo{CreatedAt: time.Unix(1, 0), Author: "alice"}}
+	actual := &document{ID: "b", Title: "Final", Meta: &auditInfo{CreatedAt: time.Unix(2, 0), Author: "alice"}}
+	EqualIgnoring(rec, expected, actual, []string{"ID", "Meta.CreatedAt"})
+	if len(rec.errors) != 1 {
+		t.Fatalf("Expected 1 failure, got %v", rec.errors)
+	}
+	if !strings.Contains(rec.errors[0], "Title: expected Draft, actual Final") || strings.Contains(rec.errors[0], "CreatedAt:") {
+		t.Errorf("Expected only Title to be reported, got:\n%s", rec.errors[0])
+	}
+	if !expected.Meta.CreatedAt.Equal(time.Unix(1, 0)) || expected.ID != "a" {
+		t.Error("Expected the arguments not to be modified")
+	}
+
+	EqualIgnoring(rec, expected, actual, []string{"Meta.Created"})
+	if len(rec.errors) != 2 || !strings.Contains(rec.errors[1], "Unknown fields of assert.document: Meta.Created") {
+		t.Errorf("Expected the unknown field to be reported, got %v", rec.errors)
+	}
+}
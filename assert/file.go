@@ -0,0 +1,120 @@
+package assert
+
+import (
+	"bytes"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/g-restante/GopeherKit.Test/internal/diff"
+)
+
+// FileContentEqual asserts that the file at path contains exactly the expected bytes.
+// On mismatch the failure message contains a line diff of the two contents.
+func FileContentEqual(t TestingT, path string, expected []byte, msg ...string) {
+	t.Helper()
+
+	actual, err := os.ReadFile(path)
+	if err != nil {
+		message := messageOrDefault(msg, "failed to read file")
+		t.Errorf("%s\nPath:  %s\nError: %v", message, path, err)
+		return
+	}
+
+	if !bytes.Equal(expected, actual) {
+		message := messageOrDefault(msg, "file content should be equal")
+		t.Errorf("%s\nPath: %s\nDiff (-expected +actual):\n%s", message, path, diff.Truncate(diff.Lines(string(expected), string(actual)), MaxDiffLines))
+	}
+}
+
+// FileContentContains asserts that the file at path contains the given substring.
+func FileContentContains(t TestingT, path string, substring string, msg ...string) {
+	t.Helper()
+
+	actual, err := os.ReadFile(path)
+	if err != nil {
+		message := messageOrDefault(msg, "failed to read file")
+		t.Errorf("%s\nPath:  %s\nError: %v", message, path, err)
+		return
+	}
+
+	if !bytes.Contains(actual, []byte(substring)) {
+		message := messageOrDefault(msg, "file should contain substring")
+		t.Errorf("%s\nPath:      %s\nSubstring: %q\nContent:\n%s", message, path, substring, actual)
+	}
+}
+
+// DirEmpty asserts that the directory at path exists and has no entries.
+func DirEmpty(t TestingT, path string, msg ...string) {
+	t.Helper()
+
+	entries, err := dirEntries(path)
+	if err != nil {
+		message := messageOrDefault(msg, "failed to read directory")
+		t.Errorf("%s\nPath:  %s\nError: %v", message, path, err)
+		return
+	}
+
+	if len(entries) > 0 {
+		message := messageOrDefault(msg, "directory should be empty")
+		t.Errorf("%s\nPath:    %s\nEntries: %s", message, path, strings.Join(entries, ", "))
+	}
+}
+
+// DirContains asserts that the directory at path holds exactly the named
+// entries, files or subdirectories, in any order. Missing and unexpected
+// entries are both reported.
+func DirContains(t TestingT, path string, names ...string) {
+	t.Helper()
+
+	entries, err := dirEntries(path)
+	if err != nil {
+		t.Errorf("failed to read directory\nPath:  %s\nError: %v", path, err)
+		return
+	}
+
+	present := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		present[entry] = true
+	}
+	wanted := make(map[string]bool, len(names))
+	var missing []string
+	for _, name := range names {
+		wanted[name] = true
+		if !present[name] {
+			missing = append(missing, name)
+		}
+	}
+	var unexpected []string
+	for _, entry := range entries {
+		if !wanted[entry] {
+			unexpected = append(unexpected, entry)
+		}
+	}
+
+	if len(missing) > 0 || len(unexpected) > 0 {
+		details := "Path: " + path
+		if len(missing) > 0 {
+			sort.Strings(missing)
+			details += "\nMissing entries: " + strings.Join(missing, ", ")
+		}
+		if len(unexpected) > 0 {
+			details += "\nUnexpected entries: " + strings.Join(unexpected, ", ")
+		}
+		t.Errorf("directory should contain exactly the expected entries\n%s", details)
+	}
+}
+
+// dirEntries returns the sorted names of the entries in the directory at path.
+func dirEntries(path string) ([]string, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Name()
+	}
+	return names, nil
+}
@@ -0,0 +1,139 @@
+package assert
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTempFile writes content to a file inside a fresh temp directory and returns its path.
+func writeTempFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "file.txt")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write temp file: %v", err)
+	}
+	return path
+}
+
+// TestFileContentEqualMatch tests that identical content passes.
+func TestFileContentEqualMatch(t *testing.T) {
+	path := writeTempFile(t, "line one\nline two\n")
+
+	rec := &recordingT{}
+	FileContentEqual(rec, path, []byte("line one\nline two\n"))
+
+	if rec.failed() {
+		t.Errorf("Expected no failure, got %v", rec.errors)
+	}
+}
+
+// TestFileContentEqualMismatch tests that differing content fails with a diff.
+func TestFileContentEqualMismatch(t *testing.T) {
+	path := writeTempFile(t, "line one\nline 2\n")
+
+	rec := &recordingT{}
+	FileContentEqual(rec, path, []byte("line one\nline two\n"))
+
+	if !rec.failed() {
+		t.Fatal("Expected a failure for mismatched content")
+	}
+
+	if !strings.Contains(rec.errors[0], "- line two") || !strings.Contains(rec.errors[0], "+ line 2") {
+		t.Errorf("Expected failure to contain a diff, got %q", rec.errors[0])
+	}
+}
+
+// TestFileContentEqualMissingFile tests that an unreadable file fails.
+func TestFileContentEqualMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.txt")
+
+	rec := &recordingT{}
+	FileContentEqual(rec, path, []byte("anything"))
+
+	if !rec.failed() {
+		t.Fatal("Expected a failure for a missing file")
+	}
+
+	if !strings.Contains(rec.errors[0], "failed to read file") {
+		t.Errorf("Expected read failure message, got %q", rec.errors[0])
+	}
+}
+
+// TestFileContentContains tests substring matching, mismatch and missing file.
+func TestFileContentContains(t *testing.T) {
+	path := writeTempFile(t, "package mocks\n\ntype UserServiceMock struct{}\n")
+
+	rec := &recordingT{}
+	FileContentContains(rec, path, "type UserServiceMock struct")
+	if rec.failed() {
+		t.Errorf("Expected no failure, got %v", rec.errors)
+	}
+
+	rec = &recordingT{}
+	FileContentContains(rec, path, "type OtherMock struct")
+	if !rec.failed() {
+		t.Error("Expected a failure for a missing substring")
+	}
+
+	rec = &recordingT{}
+	FileContentContains(rec, filepath.Join(t.TempDir(), "missing.txt"), "anything")
+	if !rec.failed() {
+		t.Error("Expected a failure for a missing file")
+	}
+}
+
+// TestDirEmpty tests an empty and a populated directory.
+func TestDirEmpty(t *testing.T) {
+	dir := t.TempDir()
+
+	rec := &recordingT{}
+	DirEmpty(rec, dir)
+	if rec.failed() {
+		t.Fatalf("Expected an empty directory to pass, got %v", rec.errors)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "leftover.txt"), nil, 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	DirEmpty(rec, dir)
+	if len(rec.errors) != 1 || !strings.Contains(rec.errors[0], "Entries: leftover.txt") {
+		t.Errorf("Expected the leftover entry to be reported, got %v", rec.errors)
+	}
+
+	DirEmpty(rec, filepath.Join(dir, "missing"))
+	if len(rec.errors) != 2 || !strings.HasPrefix(rec.errors[1], "failed to read directory") {
+		t.Errorf("Expected a missing directory to fail, got %v", rec.errors)
+	}
+}
+
+// TestDirContains tests that missing and unexpected entries are reported.
+func TestDirContains(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"user_mock.go", "order_mock.go", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "testdata"), 0755); err != nil {
+		t.Fatalf("Failed to create subdirectory: %v", err)
+	}
+
+	rec := &recordingT{}
+	DirContains(rec, dir, "user_mock.go", "testdata", "order_mock.go", "notes.txt")
+	if rec.failed() {
+		t.Fatalf("Expected the exact entries to pass, got %v", rec.errors)
+	}
+
+	DirContains(rec, dir, "user_mock.go", "order_mock.go", "testdata", "item_mock.go")
+	if len(rec.errors) != 1 {
+		t.Fatalf("Expected 1 failure, got %v", rec.errors)
+	}
+	for _, want := range []string{"Missing entries: item_mock.go", "Unexpected entries: notes.txt"} {
+		if !strings.Contains(rec.errors[0], want) {
+			t.Errorf("Expected failure to contain %q, got:\n%s", want, rec.errors[0])
+		}
+	}
+}
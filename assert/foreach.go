@@ -0,0 +1,24 @@
+package assert
+
+import (
+	"fmt"
+	"testing"
+)
+
+// ForEach runs check for every item in its own subtest named item_<index>, so
+// the failures of each element are reported separately and labeled with its
+// position, and a failing element does not hide the ones after it:
+//
+//	assert.ForEach(t, users, func(t *testing.T, i int, u User) {
+//		assert.NotEqual(t, "", u.Email)
+//	})
+func ForEach[T any](t *testing.T, items []T, check func(t *testing.T, i int, item T)) {
+	t.Helper()
+
+	for i, item := range items {
+		i, item := i, item
+		t.Run(fmt.Sprintf("item_%d", i), func(t *testing.T) {
+			check(t, i, item)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package assert
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// TestForEach tests that every item runs in a subtest named after its index
+// and that only the failing item's subtest fails. The failing run happens in
+// a child process so that it does not fail this test.
+func TestForEach(t *testing.T) {
+	if os.Getenv("GOPHERKIT_FOREACH_CHILD") == "1" {
+		ForEach(t, []int{2, 3, 4}, func(t *testing.T, i int, n int) {
+			Equal(t, 0, n%2, "expected an even number")
+		})
+		return
+	}
+
+	var seen []int
+	ForEach(t, []string{"a", "b"}, func(t *testing.T, i int, item string) {
+		seen = append(seen, i)
+		if !strings.HasSuffix(t.Name(), fmt.Sprintf("/item_%d", i)) {
+			t.Errorf("Expected the subtest to be named after index %d, got %s", i, t.Name())
+		}
+	})
+	if len(seen) != 2 {
+		t.Errorf("Expected every item to be checked, got %v", seen)
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestForEach$", "-test.v")
+	cmd.Env = append(os.Environ(), "GOPHERKIT_FOREACH_CHILD=1")
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatalf("Expected the child run to fail, got:\n%s", output)
+	}
+
+	out := string(output)
+	if !strings.Contains(out, "--- FAIL: TestForEach/item_1") {
+		t.Errorf("Expected the odd item's subtest to fail, got:\n%s", out)
+	}
+	for _, passing := range []string{"--- PASS: TestForEach/item_0", "--- PASS: TestForEach/item_2"} {
+		if !strings.Contains(out, passing) {
+			t.Errorf("Expected %q, got:\n%s", passing, out)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package assert
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/g-restante/GopeherKit.Test/internal/diff"
+)
+
+// Formatter renders the values shown in assertion failure messages, such as
+// the Expected and Actual lines of Equal.
+type Formatter interface {
+	Format(value any) string
+}
+
+// FormatterFunc adapts a function to the Formatter interface.
+type FormatterFunc func(value any) string
+
+// Format calls f(value).
+func (f FormatterFunc) Format(value any) string {
+	return f(value)
+}
+
+// DefaultFormatter renders values the way the domain presents them: values
+// implementing fmt.Stringer or error, at the top level or nested inside
+// structs, slices, maps and pointers, are shown through String() or Error(),
+// and everything else is formatted like %+v.
+var DefaultFormatter Formatter = FormatterFunc(func(value any) string {
+	var b strings.Builder
+	writeValue(&b, reflect.ValueOf(value), 0)
+	return b.String()
+})
+
+// maxFormatDepth bounds recursion into nested and self-referencing values.
+const maxFormatDepth = 10
+
+var (
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	errorType    = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// writeValue renders v for DefaultFormatter.
+func writeValue(b *strings.Builder, v reflect.Value, depth int) {
+	if !v.IsValid() {
+		b.WriteString("<nil>")
+		return
+	}
+	if s, ok := stringValue(v); ok {
+		b.WriteString(s)
+		return
+	}
+	if depth > maxFormatDepth {
+		fmt.Fprintf(b, "%+v", v)
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			b.WriteString("<nil>")
+			return
+		}
+		switch v.Elem().Kind() {
+		case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+			b.WriteString("&")
+			writeValue(b, v.Elem(), depth+1)
+		default:
+			fmt.Fprintf(b, "%v", v)
+		}
+
+	case reflect.Interface:
+		writeValue(b, v.Elem(), depth)
+
+	case reflect.Struct:
+		b.WriteString("{")
+		for i := 0; i < v.NumField(); i++ {
+			if i > 0 {
+				b.WriteString(" ")
+			}
+			b.WriteString(v.Type().Field(i).Name + ":")
+			writeValue(b, v.Field(i), depth+1)
+		}
+		b.WriteString("}")
+
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			fmt.Fprintf(b, "%v", v)
+			return
+		}
+		b.WriteString("[")
+		for i := 0; i < v.Len(); i++ {
+			if i > 0 {
+				b.WriteString(" ")
+			}
+			writeValue(b, v.Index(i), depth+1)
+		}
+		b.WriteString("]")
+
+	case reflect.Map:
+		if v.IsNil() {
+			b.WriteString("map[]")
+			return
+		}
+		b.WriteString("map[")
+		for i, key := range sortedKeys(v) {
+			if i > 0 {
+				b.WriteString(" ")
+			}
+			writeValue(b, key, depth+1)
+			b.WriteString(":")
+			writeValue(b, v.MapIndex(key), depth+1)
+		}
+		b.WriteString("]")
+
+	default:
+		fmt.Fprintf(b, "%+v", v)
+	}
+}
+
+// stringValue returns the String() or Error() text of v if its type, or a
+// pointer to it, implements fmt.Stringer or error. Nil pointers, values read
+// from unexported fields and values whose method panics are left to the
+// caller, so that a broken String method cannot crash a failure report.
+func stringValue(v reflect.Value) (s string, ok bool) {
+	defer func() {
+		if recover() != nil {
+			s, ok = "", false
+		}
+	}()
+
+	if !v.CanInterface() || (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		return "", false
+	}
+
+	if v.Kind() != reflect.Interface && v.Kind() != reflect.Ptr && !v.Type().Implements(errorType) && !v.Type().Implements(stringerType) {
+		if reflect.PtrTo(v.Type()).Implements(errorType) || reflect.PtrTo(v.Type()).Implements(stringerType) {
+			p := reflect.New(v.Type())
+			p.Elem().Set(v)
+			v = p
+		}
+	}
+
+	switch value := v.Interface().(type) {
+	case error:
+		return value.Error(), true
+	case fmt.Stringer:
+		return value.String(), true
+	}
+	return "", false
+}
+
+var formatter = DefaultFormatter
+
+// SetFormatter makes all assertions render values with f. Passing nil restores
+// DefaultFormatter. It must not be called while assertions run concurrently.
+func SetFormatter(f Formatter) {
+	if f == nil {
+		f = DefaultFormatter
+	}
+	formatter = f
+}
+
+// Format renders value with the installed Formatter, so that other packages
+// can present values the way assertion failures do.
+func Format(value any) string {
+	return formatValue(value)
+}
+
+// formatValue renders value with the installed Formatter.
+func formatValue(value any) string {
+	return formatter.Format(value)
+}
+
+// MaxDiffLines limits the number of diff lines shown in assertion failures;
+// the rest are summarized by a "... (N more)" footer. Zero or a negative
+// value shows the whole diff.
+var MaxDiffLines = 50
+
+// listFailureDetails summarizes two slices or arrays and lists the elements
+// that differ, one per line, truncated to MaxDiffLines.
+func listFailureDetails(expected, actual reflect.Value) string {
+	changes := diff.Changes(diff.Strings(listLines(expected), listLines(actual)))
+	return fmt.Sprintf("Expected: %s with %d elements\nActual:   %s with %d elements\nDiff (-expected +actual):\n%s",
+		expected.Type(), expected.Len(), actual.Type(), actual.Len(), diff.Truncate(changes, MaxDiffLines))
+}
+
+// listLines renders each element of a slice or array, following pointers so
+// that elements are shown by value rather than by address.
+func listLines(v reflect.Value) []string {
+	lines := make([]string, v.Len())
+	for i := range lines {
+		if s, ok := stringValue(v.Index(i)); ok {
+			lines[i] = s
+		} else if elem := indirect(v.Index(i)); elem.IsValid() {
+			lines[i] = fmt.Sprintf("%#v", elem.Interface())
+		} else {
+			lines[i] = "nil"
+		}
+	}
+	return lines
+}
+
+// bothBytes reports whether expected and actual are both byte slices.
+func bothBytes(expected, actual any) ([]byte, []byte, bool) {
+	a, okA := expected.([]byte)
+	b, okB := actual.([]byte)
+	return a, b, okA && okB
+}
+
+// bytesFailureDetails renders two byte slices as hex, adds a text view when
+// both are printable and names the first offset at which they differ.
+func bytesFailureDetails(expected, actual []byte) string {
+	details := fmt.Sprintf("Expected: [% x]\nActual:   [% x]", expected, actual)
+
+	if isPrintable(expected) && isPrintable(actual) {
+		details += fmt.Sprintf("\nExpected (text): %q\nActual (text):   %q", expected, actual)
+	}
+
+	offset := 0
+	for offset < len(expected) && offset < len(actual) && expected[offset] == actual[offset] {
+		offset++
+	}
+
+	switch {
+	case offset < len(expected) && offset < len(actual):
+		details += fmt.Sprintf("\nFirst difference at offset %d: expected 0x%02x, actual 0x%02x", offset, expected[offset], actual[offset])
+	case offset < len(expected):
+		details += fmt.Sprintf("\nFirst difference at offset %d: actual ends, expected 0x%02x", offset, expected[offset])
+	case offset < len(actual):
+		details += fmt.Sprintf("\nFirst difference at offset %d: expected ends, actual 0x%02x", offset, actual[offset])
+	}
+
+	return details
+}
+
+// isPrintable reports whether b is valid UTF-8 made of printable characters
+// and common whitespace.
+func isPrintable(b []byte) bool {
+	if !utf8.Valid(b) {
+		return false
+	}
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) && r != '\n' && r != '\t' && r != '\r' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,189 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestEqualBytesShowsHexAndOffset tests the byte slice failure output.
+func TestEqualBytesShowsHexAndOffset(t *testing.T) {
+	rec := &recordingT{}
+	Equal(rec, []byte{0xde, 0xad, 0xbe, 0xef}, []byte{0xde, 0xad, 0x00, 0xef})
+
+	if !rec.failed() {
+		t.Fatal("Expected a failure for differing byte slices")
+	}
+
+	message := rec.errors[0]
+	for _, want := range []string{
+		"Expected: [de ad be ef]",
+		"Actual:   [de ad 00 ef]",
+		"First difference at offset 2: expected 0xbe, actual 0x00",
+	} {
+		if !strings.Contains(message, want) {
+			t.Errorf("Expected failure to contain %q, got:\n%s", want, message)
+		}
+	}
+
+	if strings.Contains(message, "(text)") {
+		t.Errorf("Expected no text view for binary data, got:\n%s", message)
+	}
+}
+
+// TestEqualBytesPrintableAndLength tests the text view and length mismatches.
+func TestEqualBytesPrintableAndLength(t *testing.T) {
+	rec := &recordingT{}
+	Equal(rec, []byte("alice"), []byte("alice!"))
+
+	if !rec.failed() {
+		t.Fatal("Expected a failure for differing byte slices")
+	}
+
+	message := rec.errors[0]
+	for _, want := range []string{
+		`Expected (text): "alice"`,
+		`Actual (text):   "alice!"`,
+		"First difference at offset 5: expected ends, actual 0x21",
+	} {
+		if !strings.Contains(message, want) {
+			t.Errorf("Expected failure to contain %q, got:\n%s", want, message)
+		}
+	}
+}
+
+// TestEqualLargeSlicesTruncatesDiff tests that list diffs are capped at MaxDiffLines.
+func TestEqualLargeSlicesTruncatesDiff(t *testing.T) {
+	defer func(limit int) { MaxDiffLines = limit }(MaxDiffLines)
+	MaxDiffLines = 4
+
+	var expected, actual []*user
+	for i := 0; i < 100; i++ {
+		expected = append(expected, &user{ID: fmt.Sprint(i), Name: "user"})
+		actual = append(actual, &user{ID: fmt.Sprint(i), Name: "user"})
+	}
+	for i := 0; i < 5; i++ {
+		actual[i*10].Name = "renamed"
+	}
+
+	rec := &recordingT{}
+	Equal(rec, expected, actual)
+
+	if !rec.failed() {
+		t.Fatal("Expected a failure for differing slices")
+	}
+
+	message := rec.errors[0]
+	for _, want := range []string{
+		"Expected: []*assert.user with 100 elements",
+		`- assert.user{ID:"0", Name:"user", Email:""}`,
+		`+ assert.user{ID:"0", Name:"renamed", Email:""}`,
+		"... (6 more)",
+	} {
+		if !strings.Contains(message, want) {
+			t.Errorf("Expected failure to contain %q, got:\n%s", want, message)
+		}
+	}
+
+	if lines := strings.Count(message, "\n- ") + strings.Count(message, "\n+ "); lines != 4 {
+		t.Errorf("Expected 4 diff lines, got %d:\n%s", lines, message)
+	}
+}
+
+// TestSetFormatter tests that a custom formatter changes failure output and
+// that passing nil restores the default.
+func TestSetFormatter(t *testing.T) {
+	SetFormatter(FormatterFunc(func(value any) string {
+		return fmt.Sprintf("%#v", value)
+	}))
+	defer SetFormatter(nil)
+
+	rec := &recordingT{}
+	Equal(rec, user{ID: "1", Name: "Alice"}, user{ID: "1", Name: "Bob"})
+	if !rec.failed() || !strings.Contains(rec.errors[0], `Actual:   assert.user{ID:"1", Name:"Bob", Email:""}`) {
+		t.Errorf("Expected Go-syntax output from the custom formatter, got %v", rec.errors)
+	}
+
+	SetFormatter(nil)
+
+	rec = &recordingT{}
+	Equal(rec, user{ID: "1", Name: "Alice"}, user{ID: "1", Name: "Bob"})
+	if !rec.failed() || !strings.Contains(rec.errors[0], "Actual:   {ID:1 Name:Bob Email:}") {
+		t.Errorf("Expected default output after restoring the formatter, got %v", rec.errors)
+	}
+}
+
+// money has a String method whose output differs from its default format.
+type money struct {
+	Cents    int
+	Currency string
+}
+
+func (m money) String() string {
+	return fmt.Sprintf("%d.%02d %s", m.Cents/100, m.Cents%100, m.Currency)
+}
+
+type invoice struct {
+	Number string
+	Total  money
+	Lines  []money
+}
+
+// TestDefaultFormatterPrefersStringer tests that String() is used at the top
+// level and for nested fields and elements, with %+v for everything else.
+func TestDefaultFormatterPrefersStringer(t *testing.T) {
+	rec := &recordingT{}
+	Equal(rec, money{Cents: 1050, Currency: "EUR"}, money{Cents: 999, Currency: "EUR"})
+	if !rec.failed() || !strings.Contains(rec.errors[0], "Expected: 10.50 EUR\nActual:   9.99 EUR") {
+		t.Errorf("Expected top-level values to use String(), got %v", rec.errors)
+	}
+
+	got := DefaultFormatter.Format(&invoice{
+		Number: "2024-1",
+		Total:  money{Cents: 300, Currency: "EUR"},
+		Lines:  []money{{Cents: 100, Currency: "EUR"}, {Cents: 200, Currency: "EUR"}},
+	})
+	if want := "&{Number:2024-1 Total:3.00 EUR Lines:[1.00 EUR 2.00 EUR]}"; got != want {
+		t.Errorf("Expected nested values to use String():\nwant %s\ngot  %s", want, got)
+	}
+
+	rec = &recordingT{}
+	Equal(rec, []money{{Cents: 100, Currency: "EUR"}}, []money{{Cents: 100, Currency: "USD"}})
+	if !rec.failed() || !strings.Contains(rec.errors[0], "- 1.00 EUR") || !strings.Contains(rec.errors[0], "+ 1.00 USD") {
+		t.Errorf("Expected slice diffs to use String(), got %v", rec.errors)
+	}
+}
+
+// brokenStringer has a String method that always panics.
+type brokenStringer struct {
+	Name string
+}
+
+func (brokenStringer) String() string {
+	panic("broken")
+}
+
+// tags has a String method that panics on a nil receiver.
+type tags []string
+
+func (t tags) String() string {
+	return t[0]
+}
+
+// TestDefaultFormatterRecoversFromStringPanics tests that values whose String
+// method panics are rendered structurally instead of crashing the report.
+func TestDefaultFormatterRecoversFromStringPanics(t *testing.T) {
+	if got := DefaultFormatter.Format(brokenStringer{Name: "x"}); got != "{Name:x}" {
+		t.Errorf("Expected the struct fields, got %q", got)
+	}
+
+	if got := DefaultFormatter.Format(tags(nil)); got != "[]" {
+		t.Errorf("Expected the nil receiver to be rendered without String, got %q", got)
+	}
+
+	rec := &recordingT{}
+	Equal(rec, brokenStringer{Name: "x"}, brokenStringer{Name: "y"})
+	if !rec.failed() || !strings.Contains(rec.errors[0], "Actual:   {Name:y}") {
+		t.Errorf("Expected the failure to be reported, got %v", rec.errors)
+	}
+}
@@ -0,0 +1,71 @@
+package assert
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Implements asserts that object implements the interface pointed to by
+// iface, given as a nil pointer such as (*io.Reader)(nil).
+func Implements(t TestingT, iface, object any, msg ...string) {
+	t.Helper()
+	assertImplements(t, object, []any{iface}, msg)
+}
+
+// NotImplements asserts that object does not implement the interface pointed
+// to by iface, given as a nil pointer such as (*io.Closer)(nil).
+func NotImplements(t TestingT, iface, object any, msg ...string) {
+	t.Helper()
+
+	ifaceType, ok := interfaceOf(iface)
+	if !ok {
+		t.Errorf("expected a pointer to an interface such as (*io.Reader)(nil), got %T", iface)
+		return
+	}
+
+	if reflect.TypeOf(object) != nil && reflect.TypeOf(object).Implements(ifaceType) {
+		message := messageOrDefault(msg, "object should not implement interface")
+		t.Errorf("%s\nObject:    %T\nInterface: %s", message, object, ifaceType)
+	}
+}
+
+// ImplementsAll asserts that object implements every interface in ifaces,
+// each given as a nil pointer such as (*io.Reader)(nil). All interfaces that
+// are not satisfied are reported together.
+func ImplementsAll(t TestingT, object any, ifaces ...any) {
+	t.Helper()
+	assertImplements(t, object, ifaces, nil)
+}
+
+// assertImplements fails, naming every unsatisfied interface, unless object
+// implements all of ifaces.
+func assertImplements(t TestingT, object any, ifaces []any, msg []string) {
+	t.Helper()
+
+	objectType := reflect.TypeOf(object)
+	var missing []string
+	for _, iface := range ifaces {
+		ifaceType, ok := interfaceOf(iface)
+		if !ok {
+			t.Errorf("expected a pointer to an interface such as (*io.Reader)(nil), got %T", iface)
+			return
+		}
+		if objectType == nil || !objectType.Implements(ifaceType) {
+			missing = append(missing, ifaceType.String())
+		}
+	}
+
+	if len(missing) > 0 {
+		message := messageOrDefault(msg, "object does not implement all interfaces")
+		t.Errorf("%s\nObject:        %T\nNot satisfied: %s", message, object, strings.Join(missing, ", "))
+	}
+}
+
+// interfaceOf returns the interface type pointed to by iface.
+func interfaceOf(iface any) (reflect.Type, bool) {
+	ifaceType := reflect.TypeOf(iface)
+	if ifaceType == nil || ifaceType.Kind() != reflect.Ptr || ifaceType.Elem().Kind() != reflect.Interface {
+		return nil, false
+	}
+	return ifaceType.Elem(), true
+}
@@ -0,0 +1,50 @@
+package assert
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+// readWriter implements io.Reader and io.Writer but not io.Closer.
+type readWriter struct{}
+
+func (readWriter) Read(p []byte) (int, error)  { return 0, io.EOF }
+func (readWriter) Write(p []byte) (int, error) { return len(p), nil }
+
+// TestImplementsAllNamesMissingInterface tests that only the unsatisfied interface is reported.
+func TestImplementsAllNamesMissingInterface(t *testing.T) {
+	rec := &recordingT{}
+	ImplementsAll(rec, readWriter{}, (*io.Reader)(nil), (*io.Writer)(nil))
+	if rec.failed() {
+		t.Errorf("Expected both interfaces to be satisfied, got %v", rec.errors)
+	}
+
+	ImplementsAll(rec, readWriter{}, (*io.Reader)(nil), (*io.Writer)(nil), (*io.Closer)(nil))
+	if len(rec.errors) != 1 {
+		t.Fatalf("Expected 1 failure, got %v", rec.errors)
+	}
+	if !strings.Contains(rec.errors[0], "Not satisfied: io.Closer") || strings.Contains(rec.errors[0], "io.Reader") {
+		t.Errorf("Expected only io.Closer to be named, got:\n%s", rec.errors[0])
+	}
+}
+
+// TestImplementsAndNotImplements tests the single-interface variants.
+func TestImplementsAndNotImplements(t *testing.T) {
+	rec := &recordingT{}
+	Implements(rec, (*io.Writer)(nil), readWriter{})
+	NotImplements(rec, (*io.Closer)(nil), readWriter{})
+	if rec.failed() {
+		t.Errorf("Expected no failures, got %v", rec.errors)
+	}
+
+	Implements(rec, (*io.Closer)(nil), readWriter{})
+	NotImplements(rec, (*io.Reader)(nil), readWriter{})
+	Implements(rec, io.EOF, readWriter{})
+	if len(rec.errors) != 3 {
+		t.Fatalf("Expected 3 failures, got %v", rec.errors)
+	}
+	if !strings.Contains(rec.errors[2], "pointer to an interface") {
+		t.Errorf("Expected invalid interface argument to be reported, got %s", rec.errors[2])
+	}
+}
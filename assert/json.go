@@ -0,0 +1,136 @@
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// JSONContains asserts that the actual JSON document contains every key/value
+// in expectedSubset, recursively. Extra keys in actual are ignored; arrays must
+// have the same length and are compared element by element.
+func JSONContains(t TestingT, actual string, expectedSubset string, msg ...string) {
+	t.Helper()
+
+	var actualValue, subsetValue any
+	if err := json.Unmarshal([]byte(actual), &actualValue); err != nil {
+		message := messageOrDefault(msg, "actual value is not valid JSON")
+		t.Errorf("%s\nError: %v", message, err)
+		return
+	}
+	if err := json.Unmarshal([]byte(expectedSubset), &subsetValue); err != nil {
+		message := messageOrDefault(msg, "expected subset is not valid JSON")
+		t.Errorf("%s\nError: %v", message, err)
+		return
+	}
+
+	if problem := jsonSubset(actualValue, subsetValue, "$"); problem != "" {
+		message := messageOrDefault(msg, "JSON should contain expected subset")
+		t.Errorf("%s\n%s", message, problem)
+	}
+}
+
+// EqualJSONMarshaled asserts that expected and actual marshal to semantically
+// equal JSON: key order and whitespace are ignored, and so are unexported
+// fields and other state that encoding/json does not see. This compares
+// API-facing values the way a client would. On failure it reports the first
+// differing path and both documents.
+func EqualJSONMarshaled(t TestingT, expected, actual any, msg ...string) {
+	t.Helper()
+
+	expectedValue, err := marshalToJSONValue(expected)
+	if err != nil {
+		message := messageOrDefault(msg, "expected value cannot be marshaled to JSON")
+		t.Errorf("%s\nError: %v", message, err)
+		return
+	}
+	actualValue, err := marshalToJSONValue(actual)
+	if err != nil {
+		message := messageOrDefault(msg, "actual value cannot be marshaled to JSON")
+		t.Errorf("%s\nError: %v", message, err)
+		return
+	}
+
+	if problem := jsonDifference(expectedValue, actualValue); problem != "" {
+		message := messageOrDefault(msg, "values should marshal to equal JSON")
+		t.Errorf("%s\n%s\nExpected: %s\nActual:   %s", message, problem, jsonString(expectedValue), jsonString(actualValue))
+	}
+}
+
+// marshalToJSONValue marshals value and decodes the result into the generic
+// form compared by jsonDifference.
+func marshalToJSONValue(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var decoded any
+	err = json.Unmarshal(data, &decoded)
+	return decoded, err
+}
+
+// jsonDifference describes the first path at which two decoded JSON values
+// differ, or returns an empty string if they are equal.
+func jsonDifference(expected, actual any) string {
+	if problem := jsonSubset(actual, expected, "$"); problem != "" {
+		return problem
+	}
+	// Everything in expected is in actual, so only extra keys can remain.
+	if problem := jsonSubset(expected, actual, "$"); problem != "" {
+		return strings.TrimSuffix(problem, ": missing") + ": unexpected"
+	}
+	return ""
+}
+
+// jsonSubset describes the first path at which subset is not contained in
+// actual, or returns an empty string if it is fully contained.
+func jsonSubset(actual, subset any, path string) string {
+	switch expected := subset.(type) {
+	case map[string]any:
+		object, ok := actual.(map[string]any)
+		if !ok {
+			return fmt.Sprintf("%s: expected an object, got %s", path, jsonString(actual))
+		}
+		for key, value := range expected {
+			child, ok := object[key]
+			if !ok {
+				return fmt.Sprintf("%s.%s: missing", path, key)
+			}
+			if problem := jsonSubset(child, value, path+"."+key); problem != "" {
+				return problem
+			}
+		}
+		return ""
+
+	case []any:
+		array, ok := actual.([]any)
+		if !ok {
+			return fmt.Sprintf("%s: expected an array, got %s", path, jsonString(actual))
+		}
+		if len(array) != len(expected) {
+			return fmt.Sprintf("%s: expected %d elements, got %d", path, len(expected), len(array))
+		}
+		for i, value := range expected {
+			if problem := jsonSubset(array[i], value, fmt.Sprintf("%s[%d]", path, i)); problem != "" {
+				return problem
+			}
+		}
+		return ""
+
+	default:
+		if !reflect.DeepEqual(actual, subset) {
+			return fmt.Sprintf("%s: expected %s, got %s", path, jsonString(subset), jsonString(actual))
+		}
+		return ""
+	}
+}
+
+// jsonString renders a decoded JSON value back as compact JSON.
+func jsonString(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
@@ -0,0 +1,97 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+const userResponse = `{
+	"id": "123",
+	"name": "John Doe",
+	"email": "john@example.com",
+	"createdAt": "2024-03-10T12:00:00Z",
+	"roles": ["admin", "editor"],
+	"address": {"city": "Rome", "zip": "00100"}
+}`
+
+// TestJSONContainsIgnoresExtraKeys tests that a subset with nested values passes.
+func TestJSONContainsIgnoresExtraKeys(t *testing.T) {
+	rec := &recordingT{}
+	JSONContains(rec, userResponse, `{"id": "123", "address": {"city": "Rome"}, "roles": ["admin", "editor"]}`)
+
+	if rec.failed() {
+		t.Errorf("Expected no failure, got %v", rec.errors)
+	}
+}
+
+// TestJSONContainsReportsPath tests that the first mismatched or missing path is reported.
+func TestJSONContainsReportsPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		subset   string
+		expected string
+	}{
+		{"mismatched value", `{"address": {"city": "Milan"}}`, `$.address.city: expected "Milan", got "Rome"`},
+		{"missing key", `{"phone": "555"}`, `$.phone: missing`},
+		{"array element", `{"roles": ["admin", "viewer"]}`, `$.roles[1]: expected "viewer", got "editor"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recordingT{}
+			JSONContains(rec, userResponse, tt.subset)
+
+			if !rec.failed() {
+				t.Fatal("Expected a failure")
+			}
+
+			if !strings.Contains(rec.errors[0], tt.expected) {
+				t.Errorf("Expected failure to contain %q, got %q", tt.expected, rec.errors[0])
+			}
+		})
+	}
+}
+
+// TestJSONContainsInvalidJSON tests that invalid input is reported.
+func TestJSONContainsInvalidJSON(t *testing.T) {
+	rec := &recordingT{}
+	JSONContains(rec, `{"id":`, `{"id": "123"}`)
+
+	if !rec.failed() || !strings.Contains(rec.errors[0], "not valid JSON") {
+		t.Errorf("Expected an invalid JSON failure, got %v", rec.errors)
+	}
+}
+
+type apiUser struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Email    string `json:"email,omitempty"`
+	loadedAt int64
+}
+
+// TestEqualJSONMarshaled tests that unexported state is ignored while
+// differences in the marshaled form are reported with their path.
+func TestEqualJSONMarshaled(t *testing.T) {
+	rec := &recordingT{}
+	EqualJSONMarshaled(rec, apiUser{ID: "1", Name: "Alice", loadedAt: 100}, apiUser{ID: "1", Name: "Alice", loadedAt: 200})
+	EqualJSONMarshaled(rec, apiUser{ID: "1", Name: "Alice"}, map[string]any{"name": "Alice", "id": "1"})
+	if rec.failed() {
+		t.Fatalf("Expected JSON-equal values to pass, got %v", rec.errors)
+	}
+
+	EqualJSONMarshaled(rec, apiUser{ID: "1", Name: "Alice"}, apiUser{ID: "1", Name: "Bob"})
+	EqualJSONMarshaled(rec, apiUser{ID: "1"}, apiUser{ID: "1", Email: "a@example.com"})
+	EqualJSONMarshaled(rec, apiUser{ID: "1"}, make(chan int))
+	if len(rec.errors) != 3 {
+		t.Fatalf("Expected 3 failures, got %v", rec.errors)
+	}
+	for i, want := range []string{
+		`$.name: expected "Alice", got "Bob"`,
+		"$.email: unexpected",
+		"actual value cannot be marshaled to JSON\nError: json: unsupported type: chan int",
+	} {
+		if !strings.Contains(rec.errors[i], want) {
+			t.Errorf("Expected failure %d to contain %q, got:\n%s", i, want, rec.errors[i])
+		}
+	}
+}
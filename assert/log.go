@@ -0,0 +1,27 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LogEmpty asserts that nothing was logged to log, such as a buffer returned
+// by testutil.CaptureLog or a *bytes.Buffer.
+func LogEmpty(t TestingT, log fmt.Stringer, msg ...string) {
+	t.Helper()
+
+	if output := log.String(); output != "" {
+		message := messageOrDefault(msg, "expected no log output")
+		t.Errorf("%s\nLogged:\n%s", message, output)
+	}
+}
+
+// LogContains asserts that the output logged to log contains substr.
+func LogContains(t TestingT, log fmt.Stringer, substr string, msg ...string) {
+	t.Helper()
+
+	if output := log.String(); !strings.Contains(output, substr) {
+		message := messageOrDefault(msg, "log output does not contain substring")
+		t.Errorf("%s\nSubstring: %q\nLogged:\n%s", message, substr, output)
+	}
+}
@@ -0,0 +1,39 @@
+package assert
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+)
+
+// TestLogContainsAndLogEmpty tests both log assertions against a logger's buffer.
+func TestLogContainsAndLogEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.New(&buf, "", 0)
+
+	rec := &recordingT{}
+	LogEmpty(rec, &buf)
+	if rec.failed() {
+		t.Errorf("Expected an empty log to pass, got %v", rec.errors)
+	}
+
+	logger.Printf("failed to save user %s", "alice")
+
+	LogContains(rec, &buf, "failed to save user alice")
+	if rec.failed() {
+		t.Errorf("Expected the logged line to be found, got %v", rec.errors)
+	}
+
+	LogEmpty(rec, &buf)
+	LogContains(rec, &buf, "saved user")
+	if len(rec.errors) != 2 {
+		t.Fatalf("Expected 2 failures, got %v", rec.errors)
+	}
+	if !strings.Contains(rec.errors[0], "Logged:\nfailed to save user alice") {
+		t.Errorf("Expected LogEmpty to show the output, got:\n%s", rec.errors[0])
+	}
+	if !strings.Contains(rec.errors[1], `Substring: "saved user"`) {
+		t.Errorf("Expected LogContains to show the substring, got:\n%s", rec.errors[1])
+	}
+}
@@ -0,0 +1,71 @@
+package assert
+
+import (
+	"fmt"
+	"sync"
+)
+
+// MockT is a TestingT that records failures instead of reporting them, for
+// testing custom assertions:
+//
+//	mt := &assert.MockT{}
+//	IsPositive(mt, -1)
+//	assert.True(t, mt.Failed())
+//
+// The zero value is ready to use and it is safe for concurrent use.
+type MockT struct {
+	mu       sync.Mutex
+	messages []string
+	helper   bool
+	failNow  bool
+}
+
+// Helper records that the assertion marked itself as a helper.
+func (m *MockT) Helper() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.helper = true
+}
+
+// Errorf records a failure message.
+func (m *MockT) Errorf(format string, args ...any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, fmt.Sprintf(format, args...))
+}
+
+// FailNow records that the assertion asked to stop the test. Unlike
+// testing.T.FailNow it returns normally.
+func (m *MockT) FailNow() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failNow = true
+}
+
+// Failed reports whether a failure was recorded through Errorf or FailNow.
+func (m *MockT) Failed() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.messages) > 0 || m.failNow
+}
+
+// Messages returns the recorded failure messages, in order.
+func (m *MockT) Messages() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.messages...)
+}
+
+// HelperCalled reports whether Helper was called.
+func (m *MockT) HelperCalled() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.helper
+}
+
+// FailNowCalled reports whether FailNow was called.
+func (m *MockT) FailNowCalled() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.failNow
+}
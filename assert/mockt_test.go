@@ -0,0 +1,40 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestMockTRecordsEqualFailure tests recording a failing Equal.
+func TestMockTRecordsEqualFailure(t *testing.T) {
+	mt := &MockT{}
+	Equal(mt, 1, 1)
+	if mt.Failed() {
+		t.Errorf("Expected no failure for equal values, got %v", mt.Messages())
+	}
+
+	Equal(mt, "alice", "bob", "names differ")
+
+	if !mt.Failed() {
+		t.Fatal("Expected a recorded failure")
+	}
+	if !mt.HelperCalled() {
+		t.Error("Expected Equal to mark itself as a helper")
+	}
+	if messages := mt.Messages(); len(messages) != 1 || !strings.HasPrefix(messages[0], "names differ\n") {
+		t.Errorf("Unexpected messages: %v", messages)
+	}
+	if mt.FailNowCalled() {
+		t.Error("Expected Equal not to call FailNow")
+	}
+}
+
+// TestMockTFailNow tests that FailNow alone marks the MockT as failed.
+func TestMockTFailNow(t *testing.T) {
+	mt := &MockT{}
+	mt.FailNow()
+
+	if !mt.Failed() || !mt.FailNowCalled() || len(mt.Messages()) != 0 {
+		t.Errorf("Expected FailNow to be recorded without messages, got %v", mt.Messages())
+	}
+}
@@ -0,0 +1,151 @@
+package assert
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// MatchesJSONSchema asserts that the actual JSON document conforms to schema,
+// a JSON Schema document. Only a subset of JSON Schema is supported: "type"
+// (a name or a list of names), "required", "properties",
+// "additionalProperties": false, "items" and "enum"; other keywords are
+// ignored. Every violation is reported with the path of the offending value
+// and of the schema keyword it broke.
+func MatchesJSONSchema(t TestingT, actual string, schema string, msg ...string) {
+	t.Helper()
+
+	var actualValue any
+	if err := json.Unmarshal([]byte(actual), &actualValue); err != nil {
+		message := messageOrDefault(msg, "actual value is not valid JSON")
+		t.Errorf("%s\nError: %v", message, err)
+		return
+	}
+	var schemaValue map[string]any
+	if err := json.Unmarshal([]byte(schema), &schemaValue); err != nil {
+		message := messageOrDefault(msg, "schema is not a valid JSON object")
+		t.Errorf("%s\nError: %v", message, err)
+		return
+	}
+
+	if problems := validateSchema(actualValue, schemaValue, "$", "#"); len(problems) > 0 {
+		message := messageOrDefault(msg, "JSON should match schema")
+		t.Errorf("%s\n%s", message, strings.Join(problems, "\n"))
+	}
+}
+
+// validateSchema describes every way value violates schema. path locates the
+// value in the document and schemaPath the schema in the schema document.
+func validateSchema(value any, schema map[string]any, path, schemaPath string) []string {
+	violation := func(keyword, format string, args ...any) string {
+		return fmt.Sprintf("%s: %s (schema %s/%s)", path, fmt.Sprintf(format, args...), schemaPath, keyword)
+	}
+
+	if types, ok := schema["type"]; ok {
+		names := schemaTypes(types)
+		if !matchesAnyType(value, names) {
+			// The remaining keywords assume the declared type.
+			return []string{violation("type", "expected %s, got %s", strings.Join(names, " or "), jsonString(value))}
+		}
+	}
+
+	var problems []string
+
+	if enum, ok := schema["enum"].([]any); ok {
+		found := false
+		for _, allowed := range enum {
+			if reflect.DeepEqual(value, allowed) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			problems = append(problems, violation("enum", "%s is not one of %s", jsonString(value), jsonString(enum)))
+		}
+	}
+
+	if object, ok := value.(map[string]any); ok {
+		if required, ok := schema["required"].([]any); ok {
+			for _, key := range required {
+				if name, ok := key.(string); ok {
+					if _, present := object[name]; !present {
+						problems = append(problems, violation("required", "missing required key %q", name))
+					}
+				}
+			}
+		}
+
+		properties, _ := schema["properties"].(map[string]any)
+		for _, key := range sortedKeys(reflect.ValueOf(object)) {
+			name := key.String()
+			if property, ok := properties[name].(map[string]any); ok {
+				problems = append(problems, validateSchema(object[name], property, path+"."+name, schemaPath+"/properties/"+name)...)
+			} else if schema["additionalProperties"] == false {
+				problems = append(problems, violation("additionalProperties", "unexpected key %q", name))
+			}
+		}
+	}
+
+	if array, ok := value.([]any); ok {
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, elem := range array {
+				problems = append(problems, validateSchema(elem, items, fmt.Sprintf("%s[%d]", path, i), schemaPath+"/items")...)
+			}
+		}
+	}
+
+	return problems
+}
+
+// schemaTypes returns the type names allowed by a "type" keyword.
+func schemaTypes(types any) []string {
+	switch t := types.(type) {
+	case string:
+		return []string{t}
+	case []any:
+		var names []string
+		for _, name := range t {
+			if s, ok := name.(string); ok {
+				names = append(names, s)
+			}
+		}
+		return names
+	}
+	return nil
+}
+
+// matchesAnyType reports whether a decoded JSON value has one of the named
+// JSON Schema types.
+func matchesAnyType(value any, names []string) bool {
+	for _, name := range names {
+		switch v := value.(type) {
+		case nil:
+			if name == "null" {
+				return true
+			}
+		case bool:
+			if name == "boolean" {
+				return true
+			}
+		case float64:
+			if name == "number" || (name == "integer" && v == math.Trunc(v)) {
+				return true
+			}
+		case string:
+			if name == "string" {
+				return true
+			}
+		case []any:
+			if name == "array" {
+				return true
+			}
+		case map[string]any:
+			if name == "object" {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package assert
+
+import (
+	"strings"
+	"testing"
+)
+
+const userSchema = `{
+	"type": "object",
+	"required": ["id", "email"],
+	"properties": {
+		"id": {"type": "string"},
+		"email": {"type": "string"},
+		"roles": {"type": "array", "items": {"type": "string", "enum": ["admin", "editor"]}},
+		"address": {
+			"type": "object",
+			"required": ["city"],
+			"properties": {"zip": {"type": ["string", "null"]}}
+		}
+	}
+}`
+
+// TestMatchesJSONSchema tests a conforming document and one violating
+// required keys, property types and nested element types.
+func TestMatchesJSONSchema(t *testing.T) {
+	rec := &recordingT{}
+	MatchesJSONSchema(rec, userResponse, userSchema)
+	if rec.failed() {
+		t.Fatalf("Expected the user response to match, got %v", rec.errors)
+	}
+
+	MatchesJSONSchema(rec, `{"id": 123, "roles": ["admin", 7, "guest"], "address": {"zip": 100}}`, userSchema)
+	if len(rec.errors) != 1 {
+		t.Fatalf("Expected 1 failure, got %v", rec.errors)
+	}
+	for _, want := range []string{
+		`$: missing required key "email" (schema #/required)`,
+		`$.id: expected string, got 123 (schema #/properties/id/type)`,
+		`$.roles[1]: expected string, got 7 (schema #/properties/roles/items/type)`,
+		`$.roles[2]: "guest" is not one of ["admin","editor"] (schema #/properties/roles/items/enum)`,
+		`$.address: missing required key "city" (schema #/properties/address/required)`,
+		`$.address.zip: expected string or null, got 100 (schema #/properties/address/properties/zip/type)`,
+	} {
+		if !strings.Contains(rec.errors[0], want) {
+			t.Errorf("Expected failure to contain %q, got:\n%s", want, rec.errors[0])
+		}
+	}
+}
+
+// TestMatchesJSONSchemaAdditionalProperties tests rejecting undeclared keys.
+func TestMatchesJSONSchemaAdditionalProperties(t *testing.T) {
+	rec := &recordingT{}
+	MatchesJSONSchema(rec, `{"id": "1", "admin": true}`, `{"properties": {"id": {"type": "string"}}, "additionalProperties": false}`)
+
+	if len(rec.errors) != 1 || !strings.Contains(rec.errors[0], `$: unexpected key "admin" (schema #/additionalProperties)`) {
+		t.Errorf("Expected the extra key to be reported, got %v", rec.errors)
+	}
+}
@@ -0,0 +1,129 @@
+package assert
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// RegexpMatch asserts that actual matches pattern and returns the result of
+// FindStringSubmatch: the full match followed by the captured groups. It
+// returns nil if the pattern is invalid or does not match.
+func RegexpMatch(t TestingT, pattern, actual string, msg ...string) []string {
+	t.Helper()
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		message := messageOrDefault(msg, "invalid regular expression")
+		t.Errorf("%s\nPattern: %s\nError:   %v", message, pattern, err)
+		return nil
+	}
+
+	submatches := re.FindStringSubmatch(actual)
+	if submatches == nil {
+		message := messageOrDefault(msg, "string should match pattern")
+		t.Errorf("%s\nPattern: %s\nActual:  %q", message, pattern, actual)
+	}
+
+	return submatches
+}
+
+// ValidEmail asserts that email is a syntactically valid bare address such as
+// alice@example.com, as parsed by net/mail. Forms with a display name, like
+// "Alice <alice@example.com>", are rejected.
+func ValidEmail(t TestingT, email string, msg ...string) {
+	t.Helper()
+
+	addr, err := mail.ParseAddress(email)
+	if err == nil && addr.Address != email {
+		err = fmt.Errorf("expected a bare address, got %q", addr.String())
+	}
+	if err != nil {
+		message := messageOrDefault(msg, "value should be a valid email address")
+		t.Errorf("%s\nValue: %q\nError: %v", message, email, err)
+	}
+}
+
+// maxInlineString is the length, in runes, up to which single-line strings are
+// shown in full when they differ.
+const maxInlineString = 80
+
+// stringContext is the number of runes shown on each side of the first
+// difference in a long line.
+const stringContext = 30
+
+// stringFailureDetails pinpoints the first difference between two long or
+// multi-line strings by line and column, both counted from 1 with columns in
+// runes, and shows only the lines found there, cut to stringContext runes
+// around the difference. It returns "" for short single-line strings, which
+// are best shown whole.
+func stringFailureDetails(expected, actual string) string {
+	multiline := strings.Contains(expected, "\n") || strings.Contains(actual, "\n")
+	if !multiline && utf8.RuneCountInString(expected) <= maxInlineString && utf8.RuneCountInString(actual) <= maxInlineString {
+		return ""
+	}
+
+	expectedLines, actualLines := strings.Split(expected, "\n"), strings.Split(actual, "\n")
+	line := 0
+	for line < len(expectedLines) && line < len(actualLines) && expectedLines[line] == actualLines[line] {
+		line++
+	}
+
+	column := 1
+	if line < len(expectedLines) && line < len(actualLines) {
+		column = commonPrefixRunes(expectedLines[line], actualLines[line]) + 1
+	}
+
+	describe := func(lines []string) string {
+		if line >= len(lines) {
+			return "<missing>"
+		}
+		return stringWindow(lines[line], column-1)
+	}
+
+	return fmt.Sprintf("Strings differ at line %d, column %d (lengths %d and %d runes)\nExpected: %s\nActual:   %s",
+		line+1, column, utf8.RuneCountInString(expected), utf8.RuneCountInString(actual), describe(expectedLines), describe(actualLines))
+}
+
+// stringWindow quotes the runes of s within stringContext of offset, marking
+// cut ends with "...".
+func stringWindow(s string, offset int) string {
+	runes := []rune(s)
+	start, end := offset-stringContext, offset+stringContext
+	if start < 0 {
+		start = 0
+	}
+	if end > len(runes) {
+		end = len(runes)
+	}
+	if start > end {
+		start = end
+	}
+
+	window := fmt.Sprintf("%q", string(runes[start:end]))
+	if start > 0 {
+		window = "..." + window
+	}
+	if end < len(runes) {
+		window += "..."
+	}
+	return window
+}
+
+// commonPrefixRunes returns the number of runes a and b have in common at
+// their start.
+func commonPrefixRunes(a, b string) int {
+	n := 0
+	for a != "" && b != "" {
+		ra, sizeA := utf8.DecodeRuneInString(a)
+		rb, sizeB := utf8.DecodeRuneInString(b)
+		if ra != rb {
+			break
+		}
+		a, b = a[sizeA:], b[sizeB:]
+		n++
+	}
+	return n
+}
@@ -0,0 +1,107 @@
+package assert
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestRegexpMatchReturnsSubmatches tests that captured groups are returned.
+func TestRegexpMatchReturnsSubmatches(t *testing.T) {
+	rec := &recordingT{}
+	groups := RegexpMatch(rec, `user (\w+) created with id (\d+)`, "user alice created with id 42")
+
+	if rec.failed() {
+		t.Fatalf("Expected a match, got %v", rec.errors)
+	}
+
+	expected := []string{"user alice created with id 42", "alice", "42"}
+	if len(groups) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, groups)
+	}
+	for i := range expected {
+		if groups[i] != expected[i] {
+			t.Errorf("Expected group %d to be %q, got %q", i, expected[i], groups[i])
+		}
+	}
+}
+
+// TestRegexpMatchFailures tests no-match and invalid pattern failures.
+func TestRegexpMatchFailures(t *testing.T) {
+	rec := &recordingT{}
+	if groups := RegexpMatch(rec, `id (\d+)`, "no identifier here"); groups != nil || !rec.failed() {
+		t.Errorf("Expected a failure and nil groups, got %v and %v", groups, rec.errors)
+	}
+
+	rec = &recordingT{}
+	RegexpMatch(rec, `id (\d+`, "id 42")
+	if !rec.failed() || !strings.Contains(rec.errors[0], "invalid regular expression") {
+		t.Errorf("Expected an invalid pattern failure, got %v", rec.errors)
+	}
+}
+
+// TestEqualStringDiffPinpointsLine tests that multi-line strings are reported
+// by the line and column of their first difference, while short strings are
+// still shown in full.
+func TestEqualStringDiffPinpointsLine(t *testing.T) {
+	expected := "id: 123\nname: Alice\nemail: alice@example.com\nrole: admin"
+	actual := "id: 123\nname: Alice\nemail: alice@example.org\nrole: admin"
+
+	rec := &recordingT{}
+	Equal(rec, expected, actual)
+	Equal(rec, "short", "shirt")
+	Equal(rec, "line one\nline two", "line one")
+	if len(rec.errors) != 3 {
+		t.Fatalf("Expected 3 failures, got %v", rec.errors)
+	}
+
+	want := "values should be equal\nStrings differ at line 3, column 22 (lengths 56 and 56 runes)\n" +
+		"Expected: \"email: alice@example.com\"\nActual:   \"email: alice@example.org\""
+	if rec.errors[0] != want {
+		t.Errorf("Unexpected failure message:\n%s\nwant:\n%s", rec.errors[0], want)
+	}
+	if !strings.Contains(rec.errors[1], "Expected: short\nActual:   shirt") {
+		t.Errorf("Expected short strings to be shown in full, got:\n%s", rec.errors[1])
+	}
+	if !strings.Contains(rec.errors[2], "line 2, column 1") || !strings.Contains(rec.errors[2], "Actual:   <missing>") {
+		t.Errorf("Expected the missing line to be reported, got:\n%s", rec.errors[2])
+	}
+}
+
+// TestEqualLongStringShowsWindow tests that a long single-line string is
+// reported as a window around its first difference.
+func TestEqualLongStringShowsWindow(t *testing.T) {
+	prefix := strings.Repeat("é", 100)
+	suffix := strings.Repeat("x", 100)
+
+	rec := &recordingT{}
+	Equal(rec, prefix+"a"+suffix, prefix+"b"+suffix)
+	if !rec.failed() {
+		t.Fatal("Expected differing strings to fail")
+	}
+
+	want := "Strings differ at line 1, column 101 (lengths 201 and 201 runes)\n" +
+		"Expected: ...\"" + strings.Repeat("é", 30) + "a" + strings.Repeat("x", 29) + "\"...\n" +
+		"Actual:   ...\"" + strings.Repeat("é", 30) + "b" + strings.Repeat("x", 29) + "\"..."
+	if !strings.Contains(rec.errors[0], want) {
+		t.Errorf("Unexpected failure message:\n%s\nwant:\n%s", rec.errors[0], want)
+	}
+}
+
+// TestValidEmail tests valid, invalid and empty email addresses.
+func TestValidEmail(t *testing.T) {
+	rec := &recordingT{}
+	ValidEmail(rec, "alice@example.com")
+	ValidEmail(rec, "bob.smith+tests@mail.example.org")
+	if rec.failed() {
+		t.Fatalf("Expected valid addresses to pass, got %v", rec.errors)
+	}
+
+	for _, invalid := range []string{"alice.example.com", "alice@", "Alice <alice@example.com>", ""} {
+		rec := &recordingT{}
+		ValidEmail(rec, invalid)
+		if len(rec.errors) != 1 || !strings.Contains(rec.errors[0], fmt.Sprintf("Value: %q", invalid)) {
+			t.Errorf("Expected %q to be rejected with its value, got %v", invalid, rec.errors)
+		}
+	}
+}
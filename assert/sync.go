@@ -0,0 +1,45 @@
+package assert
+
+import (
+	"reflect"
+	"sync"
+)
+
+// syncTypes are the sync primitives skipped by Equal. Their state says
+// whether a lock is held or a Once has run, not what the value holds. Only
+// fields holding them by value are skipped; a *sync.Mutex field is still
+// compared like any other pointer.
+var syncTypes = map[reflect.Type]bool{
+	reflect.TypeOf(sync.Mutex{}):     true,
+	reflect.TypeOf(sync.RWMutex{}):   true,
+	reflect.TypeOf(sync.Once{}):      true,
+	reflect.TypeOf(sync.WaitGroup{}): true,
+}
+
+// hasSyncField reports whether values of typ contain a struct field holding
+// a sync primitive, directly or through pointers, slices, arrays and maps.
+func hasSyncField(typ reflect.Type) bool {
+	return findSyncField(typ, make(map[reflect.Type]bool))
+}
+
+func findSyncField(typ reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[typ] {
+		return false
+	}
+	seen[typ] = true
+
+	switch typ.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return findSyncField(typ.Elem(), seen)
+	case reflect.Map:
+		return findSyncField(typ.Key(), seen) || findSyncField(typ.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i).Type
+			if syncTypes[field] || findSyncField(field, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package assert
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+type guardedCounter struct {
+	sync.Mutex
+	Name  string
+	once  sync.Once
+	count int
+}
+
+// TestEqualIgnoresSyncFields tests that lock state is ignored while the other
+// fields are still compared.
+func TestEqualIgnoresSyncFields(t *testing.T) {
+	a := &guardedCounter{Name: "requests", count: 1}
+	b := &guardedCounter{Name: "requests", count: 1}
+	b.Lock()
+	defer b.Unlock()
+	b.once.Do(func() {})
+
+	rec := &recordingT{}
+	Equal(rec, a, b)
+	Equal(rec, []*guardedCounter{a}, []*guardedCounter{b})
+	if rec.failed() {
+		t.Fatalf("Expected lock and once state to be ignored, got %v", rec.errors)
+	}
+
+	Equal(rec, a, &guardedCounter{Name: "errors", count: 1})
+	Equal(rec, a, &guardedCounter{Name: "requests", count: 2})
+	if len(rec.errors) != 2 || !strings.Contains(rec.errors[0], "values should be equal") {
+		t.Errorf("Expected differing fields to fail, got %v", rec.errors)
+	}
+}
+
+type lockedNode struct {
+	mu   sync.Mutex
+	Name string
+	Next *lockedNode
+}
+
+// TestEqualSyncFieldsCyclic tests that a cyclic struct holding a mutex is
+// compared without recursing forever.
+func TestEqualSyncFieldsCyclic(t *testing.T) {
+	build := func(name string) *lockedNode {
+		head := &lockedNode{Name: "head"}
+		head.Next = &lockedNode{Name: name, Next: head}
+		return head
+	}
+	a, b := build("tail"), build("tail")
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	rec := &recordingT{}
+	Equal(rec, a, b)
+	if rec.failed() {
+		t.Fatalf("Expected equal cyclic nodes to pass, got %v", rec.errors)
+	}
+
+	Equal(rec, a, build("other"))
+	if len(rec.errors) != 1 {
+		t.Errorf("Expected differing cyclic nodes to fail, got %v", rec.errors)
+	}
+}
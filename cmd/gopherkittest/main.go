@@ -1,43 +1,103 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"go/parser"
+	"go/token"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/g-restante/GopeherKit.Test/internal"
 )
 
 func main() {
-	if len(os.Args) < 2 {
+	flags := flag.NewFlagSet("gopherkit-test", flag.ExitOnError)
+	flags.Usage = printUsage
+	jsonOutput := flags.Bool("json", false, "emit machine-readable JSON events")
+	forceColor := flags.Bool("color", false, "always colorize output")
+	noColor := flags.Bool("no-color", false, "never colorize output")
+	singleFile := flags.Bool("single-file", false, "write all generated mocks to one mocks.go")
+	style := flags.String("style", internal.StyleDefault, "mock style: empty for this module's mock package, or testify")
+	mockImport := flags.String("mock-import", "", "import path of the mock package used by generated mocks")
+	samePackage := flags.Bool("same-package", false, "generate test-only mocks in the interface's own package")
+	withTestMain := flags.Bool("with-testmain", false, "add a TestMain with setup and teardown to generated test boilerplate")
+	flags.Parse(os.Args[1:])
+
+	args := flags.Args()
+	if len(args) < 1 {
 		printUsage()
 		os.Exit(1)
 	}
 
-	command := os.Args[1]
+	out := newReporter(os.Stdout, *jsonOutput, colorEnabled(os.Stdout, *forceColor, *noColor))
+
+	command := args[0]
 	
 	switch command {
 	case "generate-mock":
-		if len(os.Args) < 4 {
-			fmt.Println("Usage: gopherkit-test generate-mock <interface-file> <output-dir>")
+		if len(args) < 3 {
+			fmt.Println("Usage: gopherkit-test generate-mock <interface-file>... <output-dir>")
+			os.Exit(1)
+		}
+		generateMock(out, args[1:len(args)-1], args[len(args)-1], *singleFile, *samePackage, *style, *mockImport)
+		
+	case "generate-suite":
+		if len(args) < 3 {
+			fmt.Println("Usage: gopherkit-test generate-suite <interface-file> <output-dir>")
+			os.Exit(1)
+		}
+		generateSuite(out, args[1], args[2], *mockImport)
+		
+	case "generate-stub":
+		if len(args) < 3 {
+			fmt.Println("Usage: gopherkit-test generate-stub <interface-file>... <output-dir>")
 			os.Exit(1)
 		}
-		generateMock(os.Args[2], os.Args[3])
+		generateStub(out, args[1:len(args)-1], args[len(args)-1])
 		
 	case "generate-test":
-		if len(os.Args) < 4 {
+		if len(args) < 3 {
 			fmt.Println("Usage: gopherkit-test generate-test <package-path> <output-dir>")
 			os.Exit(1)
 		}
-		generateTestBoilerplate(os.Args[2], os.Args[3])
+		generateTestBoilerplate(out, args[1], args[2], *withTestMain)
 		
 	case "generate-assertions":
-		if len(os.Args) < 4 {
+		if len(args) < 3 {
 			fmt.Println("Usage: gopherkit-test generate-assertions <output-dir> <spec1> [spec2] ...")
 			fmt.Println("Spec format: name:params:condition:defaultMessage")
 			os.Exit(1)
 		}
-		generateAssertions(os.Args[2], os.Args[3:])
+		generateAssertions(out, args[1], args[2:])
+		
+	case "list-interfaces":
+		if len(args) < 2 {
+			fmt.Println("Usage: gopherkit-test list-interfaces <file-or-dir>")
+			os.Exit(1)
+		}
+		if err := listInterfaces(os.Stdout, args[1]); err != nil {
+			out.failure("list", "Error listing interfaces", err)
+			os.Exit(1)
+		}
+		
+	case "verify":
+		if len(args) < 2 {
+			fmt.Println("Usage: gopherkit-test verify <config>")
+			os.Exit(1)
+		}
+		stale, err := verify(os.Stdout, args[1])
+		if err != nil {
+			out.failure("verify", "Error verifying generated files", err)
+			os.Exit(1)
+		}
+		if stale > 0 {
+			out.failure("verify", "Generated files are out of date", fmt.Errorf("%d stale or missing", stale))
+			os.Exit(1)
+		}
 		
 	default:
 		fmt.Printf("Unknown command: %s\n", command)
@@ -50,56 +110,156 @@ func printUsage() {
 	fmt.Println("GopherKit.Test Code Generator")
 	fmt.Println("")
 	fmt.Println("Usage:")
-	fmt.Println("  gopherkit-test generate-mock <interface-file> <output-dir>")
-	fmt.Println("  gopherkit-test generate-test <package-path> <output-dir>")
-	fmt.Println("  gopherkit-test generate-assertions <output-dir> <spec1> [spec2] ...")
+	fmt.Println("  gopherkit-test [flags] generate-mock <interface-file>... <output-dir>")
+	fmt.Println("  gopherkit-test [flags] generate-suite <interface-file> <output-dir>")
+	fmt.Println("  gopherkit-test [flags] generate-stub <interface-file>... <output-dir>")
+	fmt.Println("  gopherkit-test [flags] generate-test <package-path> <output-dir>")
+	fmt.Println("  gopherkit-test [flags] generate-assertions <output-dir> <spec1> [spec2] ...")
+	fmt.Println("  gopherkit-test list-interfaces <file-or-dir>")
+	fmt.Println("  gopherkit-test verify <config>")
+	fmt.Println("")
+	fmt.Println("Flags:")
+	fmt.Println("  --json      emit machine-readable JSON events")
+	fmt.Println("  --color     always colorize output")
+	fmt.Println("  --no-color  never colorize output (default when not a terminal)")
+	fmt.Println("  --single-file  write all generated mocks to one mocks.go")
+	fmt.Println("  --style=testify  generate mocks embedding testify's mock.Mock")
+	fmt.Println("  --mock-import=<path>  import path of the mock package used by generated mocks")
+	fmt.Println("  --same-package  write mock_<name>_test.go in the interface's own package")
+	fmt.Println("  --with-testmain  add a TestMain to generate-test output unless one exists")
 	fmt.Println("")
 	fmt.Println("Examples:")
 	fmt.Println("  gopherkit-test generate-mock ./example/user_service.go ./mocks")
+	fmt.Println("  gopherkit-test generate-suite ./example/user_service.go ./example")
+	fmt.Println("  gopherkit-test generate-stub ./example/user_service.go ./stubs")
 	fmt.Println("  gopherkit-test generate-test mypackage ./tests")
 	fmt.Println("  gopherkit-test generate-assertions ./assert \"IsPositive:value int:value > 0:expected positive value\"")
+	fmt.Println("  gopherkit-test list-interfaces ./example")
+	fmt.Println("  gopherkit-test verify ./gopherkit.conf")
+	fmt.Println("  gopherkit-test --json generate-mock ./example/user_service.go ./mocks")
+	fmt.Println("  gopherkit-test --single-file generate-mock ./repo/users.go ./repo/orders.go ./mocks")
 }
 
-func generateMock(interfaceFile, outputDir string) {
-	packageName := filepath.Base(filepath.Dir(interfaceFile))
-	generator := internal.NewGenerator(packageName, outputDir)
+func generateMock(out *reporter, interfaceFiles []string, outputDir string, singleFile, samePackage bool, style, mockImport string) {
+	generator := internal.NewGenerator(mockPackageName(interfaceFiles[0], outputDir), outputDir)
+	generator.SingleFile = singleFile
+	generator.SamePackage = samePackage
+	generator.Style = style
+	generator.MockImport = mockImport
 	
-	fmt.Printf("Generating mock for interface in %s...\n", interfaceFile)
+	out.progress("Generating mocks for interfaces in %s...", strings.Join(interfaceFiles, ", "))
 	
-	err := generator.GenerateMocks([]string{interfaceFile})
+	err := generator.GenerateMocks(interfaceFiles)
 	if err != nil {
-		fmt.Printf("Error generating mock: %v\n", err)
+		out.failure("mock", "Error generating mock", err)
 		os.Exit(1)
 	}
 	
-	fmt.Printf("Mock generated successfully in %s\n", outputDir)
+	for _, path := range generator.WrittenFiles() {
+		out.success("mock", path, fmt.Sprintf("Mock generated successfully in %s", outputDir))
+	}
 }
 
-func generateTestBoilerplate(packagePath, outputDir string) {
+func generateSuite(out *reporter, interfaceFile, outputDir, mockImport string) {
+	generator := internal.NewGenerator(mockPackageName(interfaceFile, outputDir), outputDir)
+	generator.MockImport = mockImport
+	
+	out.progress("Generating mock and test suite for interface in %s...", interfaceFile)
+	
+	err := generator.GenerateSuite(interfaceFile)
+	if err != nil {
+		out.failure("suite", "Error generating test suite", err)
+		os.Exit(1)
+	}
+	
+	for _, path := range generator.WrittenFiles() {
+		out.success("suite", path, fmt.Sprintf("Test suite generated successfully in %s", outputDir))
+	}
+}
+
+func generateStub(out *reporter, interfaceFiles []string, outputDir string) {
+	generator := internal.NewGenerator(mockPackageName(interfaceFiles[0], outputDir), outputDir)
+	
+	out.progress("Generating stubs for interfaces in %s...", strings.Join(interfaceFiles, ", "))
+	
+	err := generator.GenerateStubs(interfaceFiles)
+	if err != nil {
+		out.failure("stub", "Error generating stub", err)
+		os.Exit(1)
+	}
+	
+	for _, path := range generator.WrittenFiles() {
+		out.success("stub", path, fmt.Sprintf("Stub generated successfully in %s", outputDir))
+	}
+}
+
+// mockPackageName names the package of generated mocks after the output
+// directory. Mocks written next to the interface share its package.
+func mockPackageName(interfaceFile, outputDir string) string {
+	interfaceDir, errA := filepath.Abs(filepath.Dir(interfaceFile))
+	output, errB := filepath.Abs(outputDir)
+	if errA == nil && errB == nil && interfaceDir == output {
+		file, err := parser.ParseFile(token.NewFileSet(), interfaceFile, nil, parser.PackageClauseOnly)
+		if err == nil {
+			return file.Name.Name
+		}
+		return filepath.Base(interfaceDir)
+	}
+	return filepath.Base(output)
+}
+
+// listInterfaces prints each interface found at path with its method count
+// and file, one per line, in aligned columns.
+func listInterfaces(w io.Writer, path string) error {
+	summaries, err := internal.ListInterfaces(path)
+	if err != nil {
+		return err
+	}
+	if len(summaries) == 0 {
+		return fmt.Errorf("no interfaces found in %s", path)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, s := range summaries {
+		methods := "methods"
+		if s.Methods == 1 {
+			methods = "method"
+		}
+		fmt.Fprintf(tw, "%s\t%d %s\t%s\n", s.Name, s.Methods, methods, s.File)
+	}
+	return tw.Flush()
+}
+
+func generateTestBoilerplate(out *reporter, packagePath, outputDir string, withTestMain bool) {
 	packageName := filepath.Base(packagePath)
 	generator := internal.NewGenerator(packageName, outputDir)
+	generator.WithTestMain = withTestMain
 	
-	fmt.Printf("Generating test boilerplate for package %s...\n", packagePath)
+	out.progress("Generating test boilerplate for package %s...", packagePath)
 	
 	err := generator.GenerateTestBoilerplate(packagePath)
 	if err != nil {
-		fmt.Printf("Error generating test boilerplate: %v\n", err)
+		out.failure("test", "Error generating test boilerplate", err)
 		os.Exit(1)
 	}
 	
-	fmt.Printf("Test boilerplate generated successfully in %s\n", outputDir)
+	for _, path := range generator.WrittenFiles() {
+		out.success("test", path, fmt.Sprintf("Test boilerplate generated successfully in %s", outputDir))
+	}
 }
 
-func generateAssertions(outputDir string, specs []string) {
+func generateAssertions(out *reporter, outputDir string, specs []string) {
 	generator := internal.NewGenerator("assert", outputDir)
 	
-	fmt.Printf("Generating custom assertions...\n")
+	out.progress("Generating custom assertions...")
 	
 	err := generator.GenerateAssertions(specs)
 	if err != nil {
-		fmt.Printf("Error generating assertions: %v\n", err)
+		out.failure("assertions", "Error generating assertions", err)
 		os.Exit(1)
 	}
 	
-	fmt.Printf("Custom assertions generated successfully in %s\n", outputDir)
+	for _, path := range generator.WrittenFiles() {
+		out.success("assertions", path, fmt.Sprintf("Custom assertions generated successfully in %s", outputDir))
+	}
 }
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestReporterJSONEvents tests that JSON mode emits one parseable event per line.
+func TestReporterJSONEvents(t *testing.T) {
+	var buf bytes.Buffer
+	out := newReporter(&buf, true, false)
+
+	out.progress("Generating mock...")
+	out.success("mock", "mocks/userservice_mock.go", "Mock generated")
+	out.failure("mock", "Error generating mock", errors.New("no interface found in file"))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 JSON lines without progress output, got %q", buf.String())
+	}
+
+	var success event
+	if err := json.Unmarshal([]byte(lines[0]), &success); err != nil {
+		t.Fatalf("Failed to parse success event: %v", err)
+	}
+	if success != (event{Type: "mock", Path: "mocks/userservice_mock.go", Success: true}) {
+		t.Errorf("Unexpected success event: %+v", success)
+	}
+
+	var failure event
+	if err := json.Unmarshal([]byte(lines[1]), &failure); err != nil {
+		t.Fatalf("Failed to parse failure event: %v", err)
+	}
+	if failure.Success || failure.Error != "no interface found in file" {
+		t.Errorf("Unexpected failure event: %+v", failure)
+	}
+}
+
+// TestReporterColor tests that errors are highlighted only when color is enabled.
+func TestReporterColor(t *testing.T) {
+	var plain, colored bytes.Buffer
+
+	newReporter(&plain, false, false).failure("mock", "Error generating mock", errors.New("boom"))
+	newReporter(&colored, false, true).failure("mock", "Error generating mock", errors.New("boom"))
+
+	if strings.Contains(plain.String(), "\033[") {
+		t.Errorf("Expected no escape codes without color, got %q", plain.String())
+	}
+
+	if !strings.HasPrefix(colored.String(), colorRed) {
+		t.Errorf("Expected error highlighted in red, got %q", colored.String())
+	}
+}
+
+// TestColorDisabledForNonTerminal tests color defaults for non-TTY output.
+func TestColorDisabledForNonTerminal(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "output.txt"))
+	if err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+	defer f.Close()
+
+	if colorEnabled(f, false, false) {
+		t.Error("Expected color to be disabled when output is not a terminal")
+	}
+
+	if !colorEnabled(f, true, false) {
+		t.Error("Expected --color to force color")
+	}
+
+	if colorEnabled(f, true, true) {
+		t.Error("Expected --no-color to win over --color")
+	}
+}
+
+// TestMockPackageName tests naming generated mocks after the output directory.
+func TestMockPackageName(t *testing.T) {
+	if got := mockPackageName("../../example/user_service.go", "../../example"); got != "example" {
+		t.Errorf("Expected mocks next to the interface to share its package, got %q", got)
+	}
+
+	if got := mockPackageName("../../example/user_service.go", t.TempDir()+"/mocks"); got != "mocks" {
+		t.Errorf("Expected mocks to be named after the output directory, got %q", got)
+	}
+}
+
+// TestListInterfaces tests that each interface is printed with its method count and file.
+func TestListInterfaces(t *testing.T) {
+	path := filepath.Join("..", "..", "internal", "testdata", "shapes.go")
+
+	var buf bytes.Buffer
+	if err := listInterfaces(&buf, path); err != nil {
+		t.Fatalf("Failed to list interfaces: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 interfaces, got:\n%s", buf.String())
+	}
+	for i, want := range []string{"Shape   2 methods  ", "Named   1 method   ", "Canvas  3 methods  "} {
+		if !strings.HasPrefix(lines[i], want) || !strings.HasSuffix(lines[i], path) {
+			t.Errorf("Expected line %d to start with %q and end with the file, got %q", i, want, lines[i])
+		}
+	}
+
+	if err := listInterfaces(&buf, t.TempDir()); err == nil {
+		t.Error("Expected an error for a directory without interfaces")
+	}
+}
+
+// TestVerify tests that verify passes for up-to-date files and reports stale
+// and missing ones without rewriting them.
+func TestVerify(t *testing.T) {
+	dir := t.TempDir()
+	source, err := os.ReadFile(filepath.Join("..", "..", "internal", "testdata", "named_returns.go"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	interfaceFile := filepath.Join(dir, "finder.go")
+	if err := os.WriteFile(interfaceFile, source, 0644); err != nil {
+		t.Fatalf("Failed to write interface: %v", err)
+	}
+	config := filepath.Join(dir, "gopherkit.conf")
+	line := fmt.Sprintf("# mocks\ngenerate-mock %s %s\n", interfaceFile, dir)
+	if err := os.WriteFile(config, []byte(line), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	generations, err := parseConfig(strings.NewReader(line))
+	if err != nil || len(generations) != 1 {
+		t.Fatalf("Failed to parse config: %v, %v", generations, err)
+	}
+	if _, err := generations[0].run(false); err != nil {
+		t.Fatalf("Failed to generate mock: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if stale, err := verify(&buf, config); err != nil || stale != 0 {
+		t.Fatalf("Expected up-to-date files to pass, got %d stale, %v:\n%s", stale, err, buf.String())
+	}
+
+	mockFile := filepath.Join(dir, "finder_mock.go")
+	edited := strings.Replace(readFile(t, mockFile), "func (m *FinderMock) Count()", "func (m *FinderMock) Total()", 1)
+	if err := os.WriteFile(mockFile, []byte(edited), 0644); err != nil {
+		t.Fatalf("Failed to edit mock: %v", err)
+	}
+
+	buf.Reset()
+	stale, err := verify(&buf, config)
+	if err != nil || stale != 1 {
+		t.Fatalf("Expected 1 stale file, got %d, %v", stale, err)
+	}
+	for _, want := range []string{"Stale: " + mockFile, "- func (m *FinderMock) Total()", "+ func (m *FinderMock) Count()"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, buf.String())
+		}
+	}
+	if readFile(t, mockFile) != edited {
+		t.Error("Expected verify not to rewrite the stale file")
+	}
+
+	os.Remove(mockFile)
+	buf.Reset()
+	if stale, _ := verify(&buf, config); stale != 1 || !strings.Contains(buf.String(), "Missing: "+mockFile) {
+		t.Errorf("Expected the missing file to be reported, got %d:\n%s", stale, buf.String())
+	}
+}
+
+// TestVerifyResolvesPathsAgainstConfig tests that relative paths in the config
+// are resolved against its directory rather than the working directory.
+func TestVerifyResolvesPathsAgainstConfig(t *testing.T) {
+	dir := t.TempDir()
+	source, err := os.ReadFile(filepath.Join("..", "..", "internal", "testdata", "named_returns.go"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "finder.go"), source, 0644); err != nil {
+		t.Fatalf("Failed to write interface: %v", err)
+	}
+	config := filepath.Join(dir, "gopherkit.conf")
+	if err := os.WriteFile(config, []byte("generate-mock ./finder.go .\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	var buf bytes.Buffer
+	stale, err := verify(&buf, config)
+	if err != nil || stale != 1 || !strings.Contains(buf.String(), "Missing: "+filepath.Join(dir, "finder_mock.go")) {
+		t.Errorf("Expected the mock next to the config to be checked, got %d, %v:\n%s", stale, err, buf.String())
+	}
+}
+
+// TestParseConfigRejectsUnsupportedCommand tests that only generate commands
+// producing files that are not meant to be edited are accepted.
+func TestParseConfigRejectsUnsupportedCommand(t *testing.T) {
+	_, err := parseConfig(strings.NewReader("\nlist-interfaces ./example ./out\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2: cannot verify command \"list-interfaces\"") {
+		t.Errorf("Expected an unsupported command error, got %v", err)
+	}
+
+	_, err = parseConfig(strings.NewReader("generate-suite ./example/user_service.go ./example\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 1: cannot verify generate-suite") {
+		t.Errorf("Expected generate-suite to be rejected, got %v", err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	return string(content)
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	colorRed   = "\033[31m"
+	colorReset = "\033[0m"
+)
+
+// event is a machine-readable record of a generation step, emitted with --json.
+type event struct {
+	Type    string `json:"type"`
+	Path    string `json:"path,omitempty"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// reporter writes CLI output either as human-readable lines or as JSON events.
+type reporter struct {
+	w     io.Writer
+	json  bool
+	color bool
+}
+
+// newReporter creates a reporter writing to w.
+func newReporter(w io.Writer, jsonOutput, color bool) *reporter {
+	return &reporter{w: w, json: jsonOutput, color: color}
+}
+
+// progress prints a status line. It is suppressed in JSON mode.
+func (r *reporter) progress(format string, args ...any) {
+	if r.json {
+		return
+	}
+	fmt.Fprintf(r.w, format+"\n", args...)
+}
+
+// success reports a generated file.
+func (r *reporter) success(kind, path, message string) {
+	if r.json {
+		r.emit(event{Type: kind, Path: path, Success: true})
+		return
+	}
+	fmt.Fprintln(r.w, message)
+}
+
+// failure reports a failed generation step.
+func (r *reporter) failure(kind, message string, err error) {
+	if r.json {
+		r.emit(event{Type: kind, Success: false, Error: err.Error()})
+		return
+	}
+
+	line := fmt.Sprintf("%s: %v", message, err)
+	if r.color {
+		line = colorRed + line + colorReset
+	}
+	fmt.Fprintln(r.w, line)
+}
+
+// emit writes one event as a line of JSON.
+func (r *reporter) emit(e event) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		fmt.Fprintf(r.w, "{\"type\":%q,\"success\":false,\"error\":%q}\n", e.Type, err.Error())
+		return
+	}
+	fmt.Fprintln(r.w, string(data))
+}
+
+// colorEnabled decides whether to colorize output. An explicit --color or
+// --no-color wins; otherwise color is used only when f is a terminal.
+func colorEnabled(f *os.File, forceColor, noColor bool) bool {
+	switch {
+	case noColor:
+		return false
+	case forceColor:
+		return true
+	}
+	return isTerminal(f)
+}
+
+// isTerminal reports whether f is a character device such as a TTY.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
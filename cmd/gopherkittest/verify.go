@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/g-restante/GopeherKit.Test/internal"
+	"github.com/g-restante/GopeherKit.Test/internal/diff"
+)
+
+// generation is one generate command listed in a verify config file.
+type generation struct {
+	command     string
+	inputs      []string
+	outputDir   string
+	singleFile  bool
+	samePackage bool
+	style       string
+	mockImport  string
+}
+
+// parseConfig reads a verify config file. Each line holds one generate-mock
+// or generate-stub command with its flags, written as it would be passed to
+// gopherkit-test:
+//
+//	--single-file generate-mock ./repo/users.go ./repo/orders.go ./mocks
+//	generate-stub ./repo/users.go ./stubs
+//
+// Blank lines and lines starting with # are ignored. generate-suite is
+// rejected, since the suite it writes is a skeleton meant to be edited.
+func parseConfig(r io.Reader) ([]generation, error) {
+	var generations []generation
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		g, err := parseGeneration(strings.Fields(line))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		generations = append(generations, g)
+	}
+	return generations, scanner.Err()
+}
+
+func parseGeneration(args []string) (generation, error) {
+	var g generation
+	flags := flag.NewFlagSet("verify", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.BoolVar(&g.singleFile, "single-file", false, "")
+	flags.BoolVar(&g.samePackage, "same-package", false, "")
+	flags.StringVar(&g.style, "style", internal.StyleDefault, "")
+	flags.StringVar(&g.mockImport, "mock-import", "", "")
+	if err := flags.Parse(args); err != nil {
+		return g, err
+	}
+
+	args = flags.Args()
+	if len(args) < 3 {
+		return g, errors.New("expected a command, interface files and an output directory")
+	}
+	g.command, g.inputs, g.outputDir = args[0], args[1:len(args)-1], args[len(args)-1]
+
+	switch g.command {
+	case "generate-mock", "generate-stub":
+	case "generate-suite":
+		return g, errors.New("cannot verify generate-suite: the test suite it writes is a skeleton meant to be edited")
+	default:
+		return g, fmt.Errorf("cannot verify command %q", g.command)
+	}
+	return g, nil
+}
+
+// resolve makes the relative input files and output directory of g relative
+// to dir instead of the working directory.
+func (g *generation) resolve(dir string) {
+	for i, input := range g.inputs {
+		if !filepath.IsAbs(input) {
+			g.inputs[i] = filepath.Join(dir, input)
+		}
+	}
+	if !filepath.IsAbs(g.outputDir) {
+		g.outputDir = filepath.Join(dir, g.outputDir)
+	}
+}
+
+// run performs the generation. With dryRun set nothing is written, and the
+// generated content is available from the returned generator.
+func (g generation) run(dryRun bool) (*internal.Generator, error) {
+	generator := internal.NewGenerator(mockPackageName(g.inputs[0], g.outputDir), g.outputDir)
+	generator.SingleFile = g.singleFile
+	generator.SamePackage = g.samePackage
+	generator.Style = g.style
+	generator.MockImport = g.mockImport
+	generator.DryRun = dryRun
+
+	var err error
+	switch g.command {
+	case "generate-mock":
+		err = generator.GenerateMocks(g.inputs)
+	case "generate-stub":
+		err = generator.GenerateStubs(g.inputs)
+	}
+	return generator, err
+}
+
+// verify regenerates every file configured in configPath in memory and
+// compares it with the file on disk, without writing anything. Relative paths
+// in the config are resolved against the config file's directory. It prints a
+// diff for each stale file, lists missing ones, and returns how many files
+// are out of date.
+func verify(w io.Writer, configPath string) (int, error) {
+	config, err := os.Open(configPath)
+	if err != nil {
+		return 0, err
+	}
+	defer config.Close()
+
+	generations, err := parseConfig(config)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", configPath, err)
+	}
+
+	stale, checked := 0, 0
+	for _, g := range generations {
+		g.resolve(filepath.Dir(configPath))
+		generator, err := g.run(true)
+		if err != nil {
+			return stale, fmt.Errorf("%s %s: %w", g.command, strings.Join(g.inputs, " "), err)
+		}
+
+		for _, path := range generator.WrittenFiles() {
+			checked++
+			generated := generator.Output(path)
+			onDisk, err := os.ReadFile(path)
+			switch {
+			case errors.Is(err, os.ErrNotExist):
+				stale++
+				fmt.Fprintf(w, "Missing: %s\n", path)
+			case err != nil:
+				return stale, err
+			case string(onDisk) != generated:
+				stale++
+				fmt.Fprintf(w, "Stale: %s\nDiff (-on disk +generated):\n%s", path, diff.Changes(diff.Lines(string(onDisk), generated)))
+			}
+		}
+	}
+
+	if stale == 0 {
+		fmt.Fprintf(w, "All %d generated files are up to date\n", checked)
+	}
+	return stale, nil
+}
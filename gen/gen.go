@@ -0,0 +1,154 @@
+package gen
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"reflect"
+	"time"
+)
+
+// maxDepth bounds recursion into nested and self-referencing types.
+const maxDepth = 5
+
+const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// TestingT is the subset of *testing.T used by Fill.
+type TestingT interface {
+	Helper()
+	Name() string
+	Errorf(format string, args ...any)
+}
+
+// Option configures Fill.
+type Option func(*config)
+
+type config struct {
+	seed    int64
+	seedSet bool
+}
+
+// WithSeed overrides the seed derived from the test name.
+func WithSeed(seed int64) Option {
+	return func(c *config) {
+		c.seed = seed
+		c.seedSet = true
+	}
+}
+
+// Fill populates every exported field of the struct pointed to by v with
+// random values. Strings, numbers, slices and maps are non-empty, and nested
+// structs and pointers are filled recursively, up to five levels deep;
+// values nested deeper, such as in self-referencing types, are left at their
+// zero value. The random source is seeded from t.Name(), so the same test
+// always produces the same values unless WithSeed is given.
+func Fill(t TestingT, v any, opts ...Option) {
+	t.Helper()
+
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Ptr || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		t.Errorf("Fill expects a non-nil pointer to a struct, got %T", v)
+		return
+	}
+
+	c := &config{seed: SeedFor(t.Name())}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	fill(rand.New(rand.NewSource(c.seed)), target.Elem(), 0)
+}
+
+// SeedFor derives a deterministic seed from a test name.
+func SeedFor(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return int64(h.Sum64())
+}
+
+// Value returns a random value of type typ drawn from r, using the same
+// rules as Fill. It lets other packages, such as quick, generate inputs of
+// arbitrary types.
+func Value(r *rand.Rand, typ reflect.Type) reflect.Value {
+	v := reflect.New(typ).Elem()
+	fill(r, v, 0)
+	return v
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// fill sets v to a random value of its type.
+func fill(r *rand.Rand, v reflect.Value, depth int) {
+	if depth > maxDepth {
+		return
+	}
+
+	if v.Type() == timeType {
+		v.Set(reflect.ValueOf(time.Unix(r.Int63n(4102444800)+1, 0).UTC()))
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		b := make([]byte, 1+r.Intn(12))
+		for i := range b {
+			b[i] = letters[r.Intn(len(letters))]
+		}
+		v.SetString(string(b))
+
+	case reflect.Bool:
+		v.SetBool(r.Intn(2) == 1)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := int64(1 + r.Intn(100))
+		if r.Intn(2) == 1 {
+			n = -n
+		}
+		v.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v.SetUint(uint64(1 + r.Intn(100)))
+
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(r.Float64()*200 - 100)
+
+	case reflect.Complex64, reflect.Complex128:
+		v.SetComplex(complex(r.Float64()*200-100, r.Float64()*200-100))
+
+	case reflect.Ptr:
+		p := reflect.New(v.Type().Elem())
+		fill(r, p.Elem(), depth+1)
+		v.Set(p)
+
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				fill(r, v.Field(i), depth+1)
+			}
+		}
+
+	case reflect.Slice:
+		n := 1 + r.Intn(3)
+		s := reflect.MakeSlice(v.Type(), n, n)
+		for i := 0; i < n; i++ {
+			fill(r, s.Index(i), depth+1)
+		}
+		v.Set(s)
+
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			fill(r, v.Index(i), depth+1)
+		}
+
+	case reflect.Map:
+		n := 1 + r.Intn(3)
+		m := reflect.MakeMapWithSize(v.Type(), n)
+		for i := 0; i < n; i++ {
+			key := reflect.New(v.Type().Key()).Elem()
+			value := reflect.New(v.Type().Elem()).Elem()
+			fill(r, key, depth+1)
+			fill(r, value, depth+1)
+			m.SetMapIndex(key, value)
+		}
+		v.Set(m)
+	}
+}
@@ -0,0 +1,98 @@
+package gen
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type address struct {
+	City string
+	Zip  int
+}
+
+type user struct {
+	ID        string
+	Name      string
+	Email     string
+	Age       int
+	Score     float64
+	Tags      []string
+	Address   address
+	Manager   *user
+	CreatedAt time.Time
+	internal  string
+}
+
+// TestFillPopulatesExportedFields tests that every exported field is non-zero.
+func TestFillPopulatesExportedFields(t *testing.T) {
+	var u user
+	Fill(t, &u)
+
+	v := reflect.ValueOf(u)
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if v.Field(i).IsZero() {
+			t.Errorf("Expected field %s to be populated", field.Name)
+		}
+	}
+
+	if u.internal != "" {
+		t.Errorf("Expected unexported field to be left untouched, got %q", u.internal)
+	}
+}
+
+// TestFillIsDeterministic tests that the same test name and seed give the same values.
+func TestFillIsDeterministic(t *testing.T) {
+	var first, second user
+	Fill(t, &first)
+	Fill(t, &second)
+
+	if !reflect.DeepEqual(first, second) {
+		t.Error("Expected values seeded from the same test name to be identical")
+	}
+
+	var seeded user
+	Fill(t, &seeded, WithSeed(42))
+	if reflect.DeepEqual(first, seeded) {
+		t.Error("Expected WithSeed to change the generated values")
+	}
+}
+
+// TestFillRejectsNonStructPointer tests that invalid targets are reported.
+func TestFillRejectsNonStructPointer(t *testing.T) {
+	rec := &recordingT{T: t}
+	var n int
+	Fill(rec, &n)
+
+	if !rec.failed {
+		t.Error("Expected a failure for a non-struct pointer")
+	}
+}
+
+// recordingT wraps a real test but records failures instead of reporting them.
+type recordingT struct {
+	*testing.T
+	failed bool
+}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.failed = true
+}
+
+// TestValueGeneratesArbitraryTypes tests that Value fills non-struct types.
+func TestValueGeneratesArbitraryTypes(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	s := Value(r, reflect.TypeOf([]string{})).Interface().([]string)
+	if len(s) == 0 || s[0] == "" {
+		t.Errorf("Expected a populated slice, got %q", s)
+	}
+	if n := Value(r, reflect.TypeOf(0)).Int(); n == 0 {
+		t.Error("Expected a non-zero int")
+	}
+}
@@ -0,0 +1,101 @@
+package httpmock
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/g-restante/GopeherKit.Test/assert"
+)
+
+// TestingT is the subset of *testing.T used by Server.
+type TestingT interface {
+	assert.TestingT
+	Cleanup(func())
+}
+
+// Server is an HTTP test server that answers requests from configured expectations.
+type Server struct {
+	*httptest.Server
+
+	t            TestingT
+	mu           sync.Mutex
+	expectations []*Expectation
+}
+
+// Expectation represents an expected request and the response to send back.
+type Expectation struct {
+	method string
+	path   string
+	status int
+	body   string
+	called int
+}
+
+// NewServer starts a new mock HTTP server. The server is closed automatically
+// when the test finishes.
+func NewServer(t TestingT) *Server {
+	s := &Server{t: t}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Expect sets up an expectation for a request with the given method and path.
+// Unless configured otherwise, the server responds with 200 OK and an empty body.
+func (s *Server) Expect(method, path string) *Expectation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := &Expectation{
+		method: method,
+		path:   path,
+		status: http.StatusOK,
+	}
+	s.expectations = append(s.expectations, e)
+	return e
+}
+
+// Respond sets the status code and body sent back for the expected request.
+func (e *Expectation) Respond(status int, body string) *Expectation {
+	e.status = status
+	e.body = body
+	return e
+}
+
+// AssertExpectations verifies that every expected request was received.
+func (s *Server) AssertExpectations() {
+	s.t.Helper()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, e := range s.expectations {
+		if e.called == 0 {
+			s.t.Errorf("Expected request %s %s was not received", e.method, e.path)
+		}
+	}
+}
+
+// handle serves a request from the first matching expectation.
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	var match *Expectation
+	for _, e := range s.expectations {
+		if e.method == r.Method && e.path == r.URL.Path {
+			e.called++
+			match = e
+			break
+		}
+	}
+	s.mu.Unlock()
+
+	if match == nil {
+		s.t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		http.Error(w, "unexpected request", http.StatusNotImplemented)
+		return
+	}
+
+	w.WriteHeader(match.status)
+	w.Write([]byte(match.body))
+}
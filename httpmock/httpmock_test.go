@@ -0,0 +1,84 @@
+package httpmock
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordingT records failures and runs cleanups through the real test.
+type recordingT struct {
+	*testing.T
+	mu     sync.Mutex
+	errors []string
+}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// get performs a GET request against the server and returns status and body.
+func get(t *testing.T, s *Server, path string) (int, string) {
+	t.Helper()
+
+	resp, err := s.Client().Get(s.URL + path)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read body: %v", err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+// TestServerRespondsToExpectation tests that a registered expectation is served.
+func TestServerRespondsToExpectation(t *testing.T) {
+	s := NewServer(t)
+	s.Expect(http.MethodGet, "/users/123").Respond(http.StatusOK, `{"id":"123"}`)
+
+	status, body := get(t, s, "/users/123")
+
+	if status != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", status)
+	}
+
+	if body != `{"id":"123"}` {
+		t.Errorf("Expected body %q, got %q", `{"id":"123"}`, body)
+	}
+
+	s.AssertExpectations()
+}
+
+// TestServerUnexpectedRequest tests that unmatched requests fail the test.
+func TestServerUnexpectedRequest(t *testing.T) {
+	rec := &recordingT{T: t}
+	s := NewServer(rec)
+	s.Expect(http.MethodPost, "/users")
+
+	status, _ := get(t, s, "/orders")
+	s.AssertExpectations()
+
+	if status != http.StatusNotImplemented {
+		t.Errorf("Expected status 501 for an unexpected request, got %d", status)
+	}
+
+	if len(rec.errors) != 2 {
+		t.Fatalf("Expected two failures, got %v", rec.errors)
+	}
+
+	if !strings.Contains(rec.errors[0], "GET /orders") {
+		t.Errorf("Expected failure naming the request, got %q", rec.errors[0])
+	}
+
+	if !strings.Contains(rec.errors[1], "POST /users") {
+		t.Errorf("Expected failure naming the missed expectation, got %q", rec.errors[1])
+	}
+}
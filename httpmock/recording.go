@@ -0,0 +1,175 @@
+package httpmock
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// Mode selects whether a RecordingTransport records or replays interactions.
+type Mode int
+
+const (
+	// Replay serves responses from the fixture file without network access.
+	Replay Mode = iota
+	// Record performs real requests and saves them to the fixture file.
+	Record
+)
+
+// RecordingTransport is an http.RoundTripper for tests of code that calls
+// external APIs. In Record mode it performs real requests through Transport
+// and saves every request/response pair to a JSON fixture file; in Replay
+// mode it answers requests from that file without touching the network, so
+// the tests are deterministic:
+//
+//	mode := httpmock.Replay
+//	if *update {
+//		mode = httpmock.Record
+//	}
+//	client := &http.Client{Transport: httpmock.NewRecordingTransport("testdata/github.json", mode)}
+//
+// Requests are matched by method, URL and body. Identical requests are
+// replayed in the order they were recorded.
+type RecordingTransport struct {
+	// Transport performs the real requests in Record mode. Nil means
+	// http.DefaultTransport.
+	Transport http.RoundTripper
+
+	path string
+	mode Mode
+
+	mu           sync.Mutex
+	loaded       bool
+	interactions []interaction
+	used         []bool
+}
+
+// interaction is a recorded request/response pair as stored in the fixture.
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+type recordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+type recordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body"`
+}
+
+// NewRecordingTransport creates a transport recording to, or replaying from,
+// the fixture file at path. In Record mode the file is overwritten by the
+// first request.
+func NewRecordingTransport(path string, mode Mode) *RecordingTransport {
+	return &RecordingTransport{path: path, mode: mode}
+}
+
+// RoundTrip records or replays req.
+func (rt *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	request := recordedRequest{Method: req.Method, URL: req.URL.String(), Body: body}
+
+	if rt.mode == Record {
+		return rt.record(req, request)
+	}
+	return rt.replay(req, request)
+}
+
+// record performs req and appends the interaction to the fixture file.
+func (rt *RecordingTransport) record(req *http.Request, request recordedRequest) (*http.Response, error) {
+	transport := rt.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	rt.interactions = append(rt.interactions, interaction{
+		Request:  request,
+		Response: recordedResponse{Status: resp.StatusCode, Header: resp.Header, Body: string(data)},
+	})
+	fixture, err := json.MarshalIndent(rt.interactions, "", "  ")
+	if err == nil {
+		err = os.WriteFile(rt.path, fixture, 0644)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("httpmock: cannot save fixture %s: %w", rt.path, err)
+	}
+	return resp, nil
+}
+
+// replay answers req with the first unused recorded interaction matching it.
+func (rt *RecordingTransport) replay(req *http.Request, request recordedRequest) (*http.Response, error) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	if !rt.loaded {
+		data, err := os.ReadFile(rt.path)
+		if err != nil {
+			return nil, fmt.Errorf("httpmock: cannot load fixture: %w", err)
+		}
+		if err := json.Unmarshal(data, &rt.interactions); err != nil {
+			return nil, fmt.Errorf("httpmock: cannot parse fixture %s: %w", rt.path, err)
+		}
+		rt.used = make([]bool, len(rt.interactions))
+		rt.loaded = true
+	}
+
+	for i, recorded := range rt.interactions {
+		if rt.used[i] || recorded.Request != request {
+			continue
+		}
+		rt.used[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recorded.Response.Status, http.StatusText(recorded.Response.Status)),
+			StatusCode:    recorded.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        recorded.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader([]byte(recorded.Response.Body))),
+			ContentLength: int64(len(recorded.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("httpmock: no recorded interaction left for %s %s in %s", request.Method, request.URL, rt.path)
+}
+
+// readRequestBody reads the body of req and replaces it so that it can still
+// be sent.
+func readRequestBody(req *http.Request) (string, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return "", nil
+	}
+	data, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return "", fmt.Errorf("httpmock: cannot read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	return string(data), nil
+}
@@ -0,0 +1,63 @@
+package httpmock
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// do sends a request through client and returns the response body.
+func do(t *testing.T, client *http.Client, method, url, body string) string {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to build request: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read body: %v", err)
+	}
+	return string(data)
+}
+
+// TestRecordingTransportRecordAndReplay tests that a recorded interaction is
+// replayed with an identical body once the real server is gone.
+func TestRecordingTransportRecordAndReplay(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"echo":"` + string(body) + `","path":"` + r.URL.Path + `"}`))
+	}))
+	url := server.URL + "/users"
+	fixture := filepath.Join(t.TempDir(), "users.json")
+
+	recorder := &http.Client{Transport: NewRecordingTransport(fixture, Record)}
+	recorded := do(t, recorder, http.MethodPost, url, "alice")
+	server.Close()
+
+	if hits != 1 {
+		t.Fatalf("Expected the real server to be called once, got %d", hits)
+	}
+
+	replayer := &http.Client{Transport: NewRecordingTransport(fixture, Replay)}
+	if replayed := do(t, replayer, http.MethodPost, url, "alice"); replayed != recorded {
+		t.Errorf("Expected the replayed body %q to equal the recorded %q", replayed, recorded)
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader("bob"))
+	if _, err := replayer.Do(req); err == nil || !strings.Contains(err.Error(), "no recorded interaction left for POST") {
+		t.Errorf("Expected an unmatched body to be rejected, got %v", err)
+	}
+}
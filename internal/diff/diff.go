@@ -0,0 +1,164 @@
+// Package diff renders human-readable differences between text values for
+// failure messages.
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Lines returns a line-based diff between expected and actual. Lines only in
+// expected are prefixed with "-", lines only in actual with "+".
+func Lines(expected, actual string) string {
+	return Strings(strings.Split(expected, "\n"), strings.Split(actual, "\n"))
+}
+
+// maxCells bounds the work spent aligning the differing middle of two lists,
+// as the product of their lengths. Above it, Strings compares the lists index
+// by index instead, so huge inputs cannot stall a failing test.
+const maxCells = 1 << 24
+
+// Strings is like Lines but compares two lists of lines directly. Common
+// leading and trailing lines are matched first; the rest is aligned by a
+// longest common subsequence computed in linear space, or, if it is too large,
+// compared index by index.
+func Strings(a, b []string) string {
+	var buf strings.Builder
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	for _, line := range a[:prefix] {
+		buf.WriteString("  " + line + "\n")
+	}
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if len(midA)*len(midB) > maxCells {
+		byIndex(&buf, midA, midB)
+	} else {
+		align(&buf, midA, midB)
+	}
+	for _, line := range a[len(a)-suffix:] {
+		buf.WriteString("  " + line + "\n")
+	}
+
+	return buf.String()
+}
+
+// byIndex writes the lines of a and b that differ at the same index.
+func byIndex(buf *strings.Builder, a, b []string) {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		switch {
+		case i < len(a) && i < len(b) && a[i] == b[i]:
+			buf.WriteString("  " + a[i] + "\n")
+		default:
+			if i < len(a) {
+				buf.WriteString("- " + a[i] + "\n")
+			}
+			if i < len(b) {
+				buf.WriteString("+ " + b[i] + "\n")
+			}
+		}
+	}
+}
+
+// align writes a diff of a and b following a longest common subsequence,
+// found with Hirschberg's algorithm in space linear in len(b).
+func align(buf *strings.Builder, a, b []string) {
+	switch {
+	case len(a) == 0:
+		for _, line := range b {
+			buf.WriteString("+ " + line + "\n")
+		}
+		return
+	case len(b) == 0:
+		for _, line := range a {
+			buf.WriteString("- " + line + "\n")
+		}
+		return
+	case len(a) == 1:
+		for j, line := range b {
+			if line == a[0] {
+				align(buf, nil, b[:j])
+				buf.WriteString("  " + line + "\n")
+				align(buf, nil, b[j+1:])
+				return
+			}
+		}
+		align(buf, a, nil)
+		align(buf, nil, b)
+		return
+	}
+
+	// Split a in half and b where the common subsequences of the two halves
+	// add up to the longest.
+	mid := len(a) / 2
+	forward := lcsLengths(a[:mid], b, false)
+	backward := lcsLengths(a[mid:], b, true)
+	split, best := 0, -1
+	for j := 0; j <= len(b); j++ {
+		if n := forward[j] + backward[len(b)-j]; n > best {
+			split, best = j, n
+		}
+	}
+
+	align(buf, a[:mid], b[:split])
+	align(buf, a[mid:], b[split:])
+}
+
+// lcsLengths returns, for each j, the length of the longest common
+// subsequence of a and the first j lines of b, or of the last j lines of both
+// when reverse is set.
+func lcsLengths(a, b []string, reverse bool) []int {
+	at := func(lines []string, i int) string {
+		if reverse {
+			return lines[len(lines)-1-i]
+		}
+		return lines[i]
+	}
+
+	prev, cur := make([]int, len(b)+1), make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			switch {
+			case at(a, i) == at(b, j):
+				cur[j+1] = prev[j] + 1
+			case prev[j+1] >= cur[j]:
+				cur[j+1] = prev[j+1]
+			default:
+				cur[j+1] = cur[j]
+			}
+		}
+		prev, cur = cur, prev
+	}
+	return prev
+}
+
+// Changes returns only the lines of a Lines diff that differ, dropping the
+// unchanged context.
+func Changes(d string) string {
+	var buf strings.Builder
+	for _, line := range strings.SplitAfter(d, "\n") {
+		if strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "+ ") {
+			buf.WriteString(line)
+		}
+	}
+	return buf.String()
+}
+
+// Truncate keeps the first maxLines lines of d and replaces the rest with a
+// footer counting the omitted lines. A maxLines of zero or less keeps d whole.
+func Truncate(d string, maxLines int) string {
+	lines := strings.SplitAfter(strings.TrimSuffix(d, "\n"), "\n")
+	if maxLines <= 0 || len(lines) <= maxLines {
+		return d
+	}
+
+	kept := strings.Join(lines[:maxLines], "")
+	return fmt.Sprintf("%s... (%d more)\n", kept, len(lines)-maxLines)
+}
@@ -0,0 +1,67 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestLines tests that unchanged, removed and added lines are marked.
+func TestLines(t *testing.T) {
+	got := Lines("a\nb\nc\nd", "a\nc\nx\nd")
+	want := "  a\n- b\n  c\n+ x\n  d\n"
+	if got != want {
+		t.Errorf("Unexpected diff:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestStringsFindsLongestCommonSubsequence tests that the differing middle is
+// aligned on the lines it shares.
+func TestStringsFindsLongestCommonSubsequence(t *testing.T) {
+	a := []string{"x", "a", "b", "c", "d", "e", "y"}
+	b := []string{"x", "b", "q", "d", "e", "a", "y"}
+
+	got := Changes(Strings(a, b))
+	want := "- a\n- c\n+ q\n+ a\n"
+	if got != want {
+		t.Errorf("Unexpected changes:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestStringsLargeInputs tests that long lists are diffed without building a
+// table of their lengths' product.
+func TestStringsLargeInputs(t *testing.T) {
+	const n = 50000
+	a, b := make([]string, n), make([]string, n)
+	for i := range a {
+		a[i] = fmt.Sprint(i)
+		b[i] = fmt.Sprint(i)
+	}
+	b[n/2] = "changed"
+
+	got := Changes(Strings(a, b))
+	if want := fmt.Sprintf("- %d\n+ changed\n", n/2); got != want {
+		t.Errorf("Expected a single change, got:\n%s", Truncate(got, 10))
+	}
+
+	for i := range b {
+		b[i] = "other " + a[i]
+	}
+	got = Changes(Strings(a, b))
+	if lines := strings.Count(got, "\n"); lines != 2*n {
+		t.Errorf("Expected every line to differ, got %d changed lines", lines)
+	}
+	if !strings.HasPrefix(got, "- 0\n+ other 0\n- 1\n+ other 1\n") {
+		t.Errorf("Expected an index by index comparison, got:\n%s", Truncate(got, 4))
+	}
+}
+
+// TestTruncate tests that lines past the limit are summarized.
+func TestTruncate(t *testing.T) {
+	if got := Truncate("- a\n- b\n- c\n", 2); got != "- a\n- b\n... (1 more)\n" {
+		t.Errorf("Unexpected truncation: %q", got)
+	}
+	if got := Truncate("- a\n", 0); got != "- a\n" {
+		t.Errorf("Expected a zero limit to keep the diff, got %q", got)
+	}
+}
@@ -19,6 +19,10 @@ type InterfaceInfo struct {
 	Name    string
 	Package string
 	Methods []MethodInfo
+	// Interface is the interface type as referenced from Package, qualified
+	// when the interface is declared elsewhere. It is empty if the interface
+	// cannot be referenced, because it is unexported in another package.
+	Interface string
 }
 
 // MethodInfo represents information about a method in an interface.
@@ -35,17 +39,44 @@ type ParamInfo struct {
 	Type string
 }
 
+// ImportInfo represents an import of a generated file. Name is empty unless
+// the package has to be aliased.
+type ImportInfo struct {
+	Name string
+	Path string
+}
+
+// MockFileInfo represents a generated file holding one or more mocks.
+type MockFileInfo struct {
+	Package    string
+	MockImport ImportInfo
+	Imports    []ImportInfo
+	Mocks      []*InterfaceInfo
+}
+
 // Template constants for code generation
 const (
-	mockTemplate = `// Code generated by GopherKit.Test; DO NOT EDIT.
+	mockFileTemplate = `// Code generated by GopherKit.Test; DO NOT EDIT.
 
 package {{.Package}}
 
 import (
 	"testing"
-	"github.com/g-restante/GopeherKit.Test/mock"
+	{{with .MockImport}}{{if .Name}}{{.Name}} {{end}}"{{.Path}}"{{end}}
+{{- range .Imports}}
+	{{if .Name}}{{.Name}} {{end}}"{{.Path}}"
+{{- end}}
 )
+{{template "assertions" .}}
+{{- range .Mocks}}{{template "mock" .}}{{end}}`
+
+	// interfaceAssertionsTemplate makes a mock that drifts from its interface
+	// a compile error.
+	interfaceAssertionsTemplate = `{{range .Mocks}}{{if .Interface}}
+var _ {{.Interface}} = (*{{.Name}}Mock)(nil){{end}}{{end}}
+`
 
+	mockTemplate = `
 // {{.Name}}Mock is a mock implementation of {{.Name}}.
 type {{.Name}}Mock struct {
 	mock *mock.Mock
@@ -60,18 +91,27 @@ func New{{.Name}}Mock(t *testing.T) *{{.Name}}Mock {
 
 {{range .Methods}}
 // {{.Name}} is a mock implementation of the {{.Name}} method.
-func (m *{{$.Name}}Mock) {{.Name}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{.Name}} {{.Type}}{{end}}) ({{range $i, $r := .Returns}}{{if $i}}, {{end}}{{.Type}}{{end}}) {
-	args := []any{ {{range .Params}}{{.Name}}, {{end}} }
-	results := m.mock.Called("{{.Name}}", args...)
-	{{if .Returns}}
-	return {{range $i, $r := .Returns}}{{if $i}}, {{end}}results[{{$i}}].({{.Type}}){{end}}
-	{{end}}
+func (m *{{$.Name}}Mock) {{.Name}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{.Name}} {{.Type}}{{end}}){{if .Returns}} ({{range $i, $r := .Returns}}{{if $i}}, {{end}}{{.Name}} {{.Type}}{{end}}){{end}} {
+	{{if .Returns}}results := {{end}}m.mock.Called("{{.Name}}"{{range .Params}}, {{.Name}}{{end}})
+	{{- if .Returns}}
+	if len(results) < {{len .Returns}} {
+		return
+	}
+	{{- range $i, $r := .Returns}}
+	{{.Name}} = mock.ResultAs[{{.Type}}](results, {{$i}})
+	{{- end}}
+	return
+	{{- end}}
 }
 
 // On{{.Name}} sets up an expectation for the {{.Name}} method.
 func (m *{{$.Name}}Mock) On{{.Name}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{.Name}} {{.Type}}{{end}}) *mock.Call {
-	args := []any{ {{range .Params}}{{.Name}}, {{end}} }
-	return m.mock.On("{{.Name}}", args...)
+	return m.mock.On("{{.Name}}"{{range .Params}}, {{.Name}}{{end}})
+}
+
+// Assert{{.Name}}Called asserts that {{.Name}} was called with matching arguments.
+func (m *{{$.Name}}Mock) Assert{{.Name}}Called({{range $i, $p := .Params}}{{if $i}}, {{end}}{{.Name}} {{.Type}}{{end}}) {
+	m.mock.AssertCalled("{{.Name}}"{{range .Params}}, {{.Name}}{{end}})
 }
 {{end}}
 
@@ -99,6 +139,17 @@ func Test{{.Name}}(t *testing.T) {
 	// assert.True(t, condition, "description")
 	// assert.NotNil(t, value, "description")
 }
+{{- if .WithTestMain}}
+
+// TestMain runs package-level setup before the tests and teardown after them.
+func TestMain(m *testing.M) {
+	// TODO: Add setup shared by all tests here
+
+	m.Run()
+
+	// TODO: Add teardown here
+}
+{{- end}}
 `
 
 	assertionTemplate = `// Custom assertion for {{.Name}}
@@ -119,6 +170,14 @@ func {{.Name}}(t *testing.T, {{.Params}}, msg ...string) {
 `
 )
 
+// Mock styles supported by the generator.
+const (
+	// StyleDefault generates mocks built on this module's mock package.
+	StyleDefault = ""
+	// StyleTestify generates mocks embedding testify's mock.Mock.
+	StyleTestify = "testify"
+)
+
 // Generator holds the configuration and state for code generation.
 type Generator struct {
 	// PackageName is the target package for generated code
@@ -127,6 +186,30 @@ type Generator struct {
 	OutputDir string
 	// Templates holds the code templates for generation
 	Templates map[string]string
+	// SingleFile makes GenerateMocks write every mock to one mocks.go file
+	SingleFile bool
+	// Style selects the mock API generated code targets: StyleDefault or
+	// StyleTestify
+	Style string
+	// MockImport overrides the import path of the mock package generated
+	// mocks are built on, for vendored or relocated copies. Empty means the
+	// canonical path for Style.
+	MockImport string
+	// WithTestMain makes GenerateTestBoilerplate add a TestMain with setup
+	// and teardown placeholders, unless the package's tests already declare
+	// one
+	WithTestMain bool
+	// SamePackage generates each mock into the package of its interface,
+	// referencing its types unqualified, and names the file
+	// mock_<name>_test.go so that it is only compiled into that package's
+	// tests. PackageName is ignored for mocks.
+	SamePackage bool
+	// DryRun keeps generated files in memory instead of writing them; their
+	// content is available from Output
+	DryRun bool
+
+	written []string
+	output  map[string]string
 }
 
 // NewGenerator creates a new code generator instance.
@@ -139,19 +222,39 @@ func NewGenerator(packageName, outputDir string) *Generator {
 }
 
 // GenerateMocks generates mock implementations for the given interfaces.
+// Each mock is written to its own <name>_mock.go file, or, when SingleFile is
+// set, all of them are written to mocks.go with a merged import block. With
+// SamePackage the files are mock_<name>_test.go and mocks_test.go instead.
 func (g *Generator) GenerateMocks(interfaces []string) error {
+	if g.Style != StyleDefault && g.Style != StyleTestify {
+		return fmt.Errorf("unknown mock style %q", g.Style)
+	}
+
+	if g.SingleFile {
+		return g.generateMockFile(interfaces)
+	}
+
 	for _, interfacePath := range interfaces {
-		interfaceInfo, err := g.parseInterface(interfacePath)
+		imports := newImportSet(g.mockImportPath())
+		interfaceInfo, err := g.parseInterface(interfacePath, imports)
 		if err != nil {
 			return fmt.Errorf("failed to parse interface %s: %w", interfacePath, err)
 		}
 
-		mockCode, err := g.generateMockCode(interfaceInfo)
+		mockCode, err := g.generateMockCode(&MockFileInfo{
+			Package:    interfaceInfo.Package,
+			MockImport: g.mockImport(),
+			Imports:    imports.list,
+			Mocks:      []*InterfaceInfo{interfaceInfo},
+		})
 		if err != nil {
 			return fmt.Errorf("failed to generate mock for %s: %w", interfaceInfo.Name, err)
 		}
 
 		outputPath := filepath.Join(g.OutputDir, strings.ToLower(interfaceInfo.Name)+"_mock.go")
+		if g.SamePackage {
+			outputPath = filepath.Join(g.OutputDir, "mock_"+strings.ToLower(interfaceInfo.Name)+"_test.go")
+		}
 		if err := g.writeFile(outputPath, mockCode); err != nil {
 			return fmt.Errorf("failed to write mock file %s: %w", outputPath, err)
 		}
@@ -159,16 +262,65 @@ func (g *Generator) GenerateMocks(interfaces []string) error {
 	return nil
 }
 
-// GenerateTestBoilerplate generates test file templates.
+// generateMockFile writes the mocks for all interfaces to a single mocks.go.
+// Imports are deduplicated, and packages whose names collide are aliased.
+func (g *Generator) generateMockFile(interfaces []string) error {
+	imports := newImportSet(g.mockImportPath())
+	file := &MockFileInfo{Package: g.PackageName, MockImport: g.mockImport()}
+
+	for _, interfacePath := range interfaces {
+		interfaceInfo, err := g.parseInterface(interfacePath, imports)
+		if err != nil {
+			return fmt.Errorf("failed to parse interface %s: %w", interfacePath, err)
+		}
+		if g.SamePackage && len(file.Mocks) > 0 && interfaceInfo.Package != file.Package {
+			return fmt.Errorf("interface %s is in package %s, not %s; --same-package needs all interfaces in one package", interfaceInfo.Name, interfaceInfo.Package, file.Package)
+		}
+		file.Package = interfaceInfo.Package
+		file.Mocks = append(file.Mocks, interfaceInfo)
+	}
+	file.Imports = imports.list
+
+	mockCode, err := g.generateMockCode(file)
+	if err != nil {
+		return fmt.Errorf("failed to generate mocks: %w", err)
+	}
+
+	outputPath := filepath.Join(g.OutputDir, "mocks.go")
+	if g.SamePackage {
+		outputPath = filepath.Join(g.OutputDir, "mocks_test.go")
+	}
+	if err := g.writeFile(outputPath, mockCode); err != nil {
+		return fmt.Errorf("failed to write mock file %s: %w", outputPath, err)
+	}
+	return nil
+}
+
+// GenerateTestBoilerplate generates test file templates. With WithTestMain set
+// the file also declares a TestMain, unless another test file in OutputDir
+// already does.
 func (g *Generator) GenerateTestBoilerplate(packagePath string) error {
 	packageName := filepath.Base(packagePath)
 	
+	outputPath := filepath.Join(g.OutputDir, packageName+"_test.go")
+
+	withTestMain := g.WithTestMain
+	if withTestMain {
+		declared, err := declaresTestMain(g.OutputDir, outputPath)
+		if err != nil {
+			return err
+		}
+		withTestMain = !declared
+	}
+
 	testData := struct {
-		Package string
-		Name    string
+		Package      string
+		Name         string
+		WithTestMain bool
 	}{
-		Package: packageName,
-		Name:    strings.Title(packageName),
+		Package:      packageName,
+		Name:         strings.Title(packageName),
+		WithTestMain: withTestMain,
 	}
 
 	tmpl, err := template.New("test").Parse(testTemplate)
@@ -181,10 +333,34 @@ func (g *Generator) GenerateTestBoilerplate(packagePath string) error {
 		return fmt.Errorf("failed to execute test template: %w", err)
 	}
 
-	outputPath := filepath.Join(g.OutputDir, packageName+"_test.go")
 	return g.writeFile(outputPath, buf.String())
 }
 
+// declaresTestMain reports whether a test file in dir other than skip
+// declares a TestMain function. A missing dir declares none.
+func declaresTestMain(dir, skip string) (bool, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*_test.go"))
+	if err != nil {
+		return false, err
+	}
+
+	for _, path := range paths {
+		if path == skip {
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return false, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == "TestMain" {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
 // AssertionSpec represents a custom assertion specification.
 type AssertionSpec struct {
 	Name           string
@@ -225,36 +401,75 @@ func (g *Generator) GenerateAssertions(assertionSpecs []string) error {
 	return g.writeFile(outputPath, allAssertions.String())
 }
 
+// WrittenFiles returns the paths of the files written by this generator, in order.
+func (g *Generator) WrittenFiles() []string {
+	return g.written
+}
+
+// Output returns the content generated for path during a dry run.
+func (g *Generator) Output(path string) string {
+	return g.output[path]
+}
+
 // Helper functions
 
 // parseInterface parses a Go interface from a file and extracts its information.
-func (g *Generator) parseInterface(interfacePath string) (*InterfaceInfo, error) {
+// Packages referenced by the method signatures are added to imports, and
+// their qualifiers are rewritten to the names chosen there. When the mock is
+// generated into another package, types declared next to the interface are
+// qualified with the interface's own package.
+func (g *Generator) parseInterface(interfacePath string, imports *importSet) (*InterfaceInfo, error) {
 	fset := token.NewFileSet()
 	file, err := parser.ParseFile(fset, interfacePath, nil, parser.ParseComments)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse file: %w", err)
 	}
 
-	var interfaceInfo *InterfaceInfo
+	var typeSpec *ast.TypeSpec
 	ast.Inspect(file, func(n ast.Node) bool {
-		if typeSpec, ok := n.(*ast.TypeSpec); ok {
-			if interfaceType, ok := typeSpec.Type.(*ast.InterfaceType); ok {
-				interfaceInfo = &InterfaceInfo{
-					Name:    typeSpec.Name.Name,
-					Package: g.PackageName,
-					Methods: g.extractMethods(interfaceType),
-				}
+		if spec, ok := n.(*ast.TypeSpec); ok {
+			if _, ok := spec.Type.(*ast.InterfaceType); ok {
+				typeSpec = spec
 				return false
 			}
 		}
-		return true
+		return typeSpec == nil
 	})
 
-	if interfaceInfo == nil {
+	if typeSpec == nil {
 		return nil, fmt.Errorf("no interface found in file")
 	}
 
-	return interfaceInfo, nil
+	interfaceType := typeSpec.Type.(*ast.InterfaceType)
+	qualifyImports(interfaceType, file, imports)
+
+	packageName := g.PackageName
+	if g.SamePackage {
+		packageName = file.Name.Name
+	}
+
+	qualified := typeSpec.Name.Name
+	if file.Name.Name != packageName {
+		sourcePath, err := packageImportPath(filepath.Dir(interfacePath))
+		if err != nil {
+			return nil, err
+		}
+		sourceName := imports.addNamed(sourcePath, file.Name.Name)
+		if err := qualifyLocalTypes(interfaceType, sourceName); err != nil {
+			return nil, err
+		}
+		qualified = ""
+		if typeSpec.Name.IsExported() {
+			qualified = sourceName + "." + typeSpec.Name.Name
+		}
+	}
+
+	return &InterfaceInfo{
+		Name:      typeSpec.Name.Name,
+		Package:   packageName,
+		Methods:   g.extractMethods(interfaceType),
+		Interface: qualified,
+	}, nil
 }
 
 // extractMethods extracts method information from an interface type.
@@ -266,8 +481,8 @@ func (g *Generator) extractMethods(interfaceType *ast.InterfaceType) []MethodInf
 			for _, name := range method.Names {
 				methodInfo := MethodInfo{
 					Name:    name.Name,
-					Params:  g.extractParams(funcType.Params),
-					Returns: g.extractParams(funcType.Results),
+					Params:  g.extractParams(funcType.Params, "arg"),
+					Returns: g.extractParams(funcType.Results, "ret"),
 				}
 				methods = append(methods, methodInfo)
 			}
@@ -278,26 +493,32 @@ func (g *Generator) extractMethods(interfaceType *ast.InterfaceType) []MethodInf
 }
 
 // extractParams extracts parameter information from a field list.
-func (g *Generator) extractParams(fieldList *ast.FieldList) []ParamInfo {
+// Unnamed and blank parameters are given a name made of prefix and their
+// position, so named and unnamed forms can be emitted the same way.
+func (g *Generator) extractParams(fieldList *ast.FieldList, prefix string) []ParamInfo {
 	if fieldList == nil {
 		return nil
 	}
 
 	var params []ParamInfo
-	for i, field := range fieldList.List {
+	for _, field := range fieldList.List {
 		paramType := g.typeToString(field.Type)
 		
 		if len(field.Names) == 0 {
 			// Unnamed parameter
 			params = append(params, ParamInfo{
-				Name: fmt.Sprintf("arg%d", i),
+				Name: fmt.Sprintf("%s%d", prefix, len(params)),
 				Type: paramType,
 			})
 		} else {
 			// Named parameters
 			for _, name := range field.Names {
+				paramName := name.Name
+				if paramName == "_" {
+					paramName = fmt.Sprintf("%s%d", prefix, len(params))
+				}
 				params = append(params, ParamInfo{
-					Name: name.Name,
+					Name: paramName,
 					Type: paramType,
 				})
 			}
@@ -321,6 +542,21 @@ func (g *Generator) typeToString(expr ast.Expr) string {
 		return "[" + g.typeToString(t.Len) + "]" + g.typeToString(t.Elt)
 	case *ast.SelectorExpr:
 		return g.typeToString(t.X) + "." + t.Sel.Name
+	case *ast.MapType:
+		return "map[" + g.typeToString(t.Key) + "]" + g.typeToString(t.Value)
+	case *ast.ChanType:
+		switch t.Dir {
+		case ast.SEND:
+			return "chan<- " + g.typeToString(t.Value)
+		case ast.RECV:
+			return "<-chan " + g.typeToString(t.Value)
+		default:
+			return "chan " + g.typeToString(t.Value)
+		}
+	case *ast.FuncType:
+		return "func" + g.signatureToString(t)
+	case *ast.Ellipsis:
+		return "..." + g.typeToString(t.Elt)
 	case *ast.InterfaceType:
 		return "interface{}"
 	default:
@@ -328,15 +564,55 @@ func (g *Generator) typeToString(expr ast.Expr) string {
 	}
 }
 
-// generateMockCode generates mock code for an interface.
-func (g *Generator) generateMockCode(interfaceInfo *InterfaceInfo) (string, error) {
-	tmpl, err := template.New("mock").Parse(mockTemplate)
+// signatureToString converts the parameters and results of a function type
+// to a string, dropping their names.
+func (g *Generator) signatureToString(funcType *ast.FuncType) string {
+	fieldTypes := func(fieldList *ast.FieldList) []string {
+		var types []string
+		if fieldList == nil {
+			return types
+		}
+		for _, field := range fieldList.List {
+			fieldType := g.typeToString(field.Type)
+			for i := 0; i < len(field.Names) || i == 0 && len(field.Names) == 0; i++ {
+				types = append(types, fieldType)
+			}
+		}
+		return types
+	}
+
+	signature := "(" + strings.Join(fieldTypes(funcType.Params), ", ") + ")"
+	results := fieldTypes(funcType.Results)
+	switch len(results) {
+	case 0:
+		return signature
+	case 1:
+		return signature + " " + results[0]
+	default:
+		return signature + " (" + strings.Join(results, ", ") + ")"
+	}
+}
+
+// generateMockCode generates the code of a file holding one or more mocks.
+func (g *Generator) generateMockCode(file *MockFileInfo) (string, error) {
+	fileTemplate, methodsTemplate := mockFileTemplate, mockTemplate
+	if g.Style == StyleTestify {
+		fileTemplate, methodsTemplate = testifyFileTemplate, testifyMockTemplate
+	}
+
+	tmpl, err := template.New("file").Funcs(template.FuncMap{"testifyGetter": testifyGetter}).Parse(fileTemplate)
+	if err == nil {
+		_, err = tmpl.New("mock").Parse(methodsTemplate)
+	}
+	if err == nil {
+		_, err = tmpl.New("assertions").Parse(interfaceAssertionsTemplate)
+	}
 	if err != nil {
 		return "", fmt.Errorf("failed to parse mock template: %w", err)
 	}
 
 	var buf strings.Builder
-	if err := tmpl.Execute(&buf, interfaceInfo); err != nil {
+	if err := tmpl.Execute(&buf, file); err != nil {
 		return "", fmt.Errorf("failed to execute mock template: %w", err)
 	}
 
@@ -359,8 +635,18 @@ func (g *Generator) parseAssertionSpec(spec string) (*AssertionSpec, error) {
 	}, nil
 }
 
-// writeFile writes content to a file, creating directories as needed.
+// writeFile writes content to a file, creating directories as needed. During a
+// dry run the content is only kept for Output.
 func (g *Generator) writeFile(path, content string) error {
+	if g.DryRun {
+		if g.output == nil {
+			g.output = make(map[string]string)
+		}
+		g.output[path] = content
+		g.written = append(g.written, path)
+		return nil
+	}
+
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return fmt.Errorf("failed to create directory %s: %w", dir, err)
@@ -376,5 +662,6 @@ func (g *Generator) writeFile(path, content string) error {
 		return fmt.Errorf("failed to write to file %s: %w", path, err)
 	}
 
+	g.written = append(g.written, path)
 	return nil
 }
@@ -1,8 +1,13 @@
 package internal
 
 import (
+	"fmt"
+	"go/parser"
+	"go/token"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -60,6 +65,46 @@ func TestGenerateTestBoilerplate(t *testing.T) {
 	}
 }
 
+// TestGenerateTestBoilerplateWithTestMain tests that TestMain is emitted only
+// when requested and not already declared by the package's tests.
+func TestGenerateTestBoilerplateWithTestMain(t *testing.T) {
+	tests := []struct {
+		name         string
+		withTestMain bool
+		existing     string
+		want         bool
+	}{
+		{"not requested", false, "", false},
+		{"requested", true, "", true},
+		{"already declared", true, "package mypackage_test\n\nimport \"testing\"\n\nfunc TestMain(m *testing.M) { m.Run() }\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.existing != "" {
+				if err := os.WriteFile(filepath.Join(dir, "main_test.go"), []byte(tt.existing), 0644); err != nil {
+					t.Fatalf("Failed to write existing test: %v", err)
+				}
+			}
+
+			gen := NewGenerator("mypackage", dir)
+			gen.WithTestMain = tt.withTestMain
+			if err := gen.GenerateTestBoilerplate("mypackage"); err != nil {
+				t.Fatalf("Failed to generate test boilerplate: %v", err)
+			}
+
+			content, err := os.ReadFile(filepath.Join(dir, "mypackage_test.go"))
+			if err != nil {
+				t.Fatalf("Failed to read generated file: %v", err)
+			}
+			if got := contains(string(content), "func TestMain(m *testing.M) {\n"); got != tt.want {
+				t.Errorf("Expected TestMain present to be %v, got:\n%s", tt.want, content)
+			}
+		})
+	}
+}
+
 // TestGenerateAssertions tests custom assertion generation.
 func TestGenerateAssertions(t *testing.T) {
 	tempDir, err := os.MkdirTemp("", "gopherkit_test_")
@@ -142,6 +187,505 @@ func TestParseAssertionSpecInvalidFormat(t *testing.T) {
 	}
 }
 
+// TestGenerateMocksNamedReturns tests that named and unnamed results produce a working mock.
+func TestGenerateMocksNamedReturns(t *testing.T) {
+	dir := t.TempDir()
+	copyFixture(t, "named_returns.go", filepath.Join(dir, "finder.go"))
+	copyFixture(t, "named_returns_test.go.txt", filepath.Join(dir, "finder_test.go"))
+
+	gen := NewGenerator("fixture", dir)
+	if err := gen.GenerateMocks([]string{filepath.Join(dir, "finder.go")}); err != nil {
+		t.Fatalf("Failed to generate mock: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "finder_mock.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+
+	contentStr := string(content)
+	if !contains(contentStr, "var _ Finder = (*FinderMock)(nil)") {
+		t.Error("Generated mock should assert that it implements Finder")
+	}
+
+	if !contains(contentStr, "func (m *FinderMock) Find(id string) (user *User, err error)") {
+		t.Error("Generated mock should keep declared result names")
+	}
+
+	if !contains(contentStr, "func (m *FinderMock) Count() (ret0 int)") {
+		t.Error("Generated mock should name unnamed results")
+	}
+
+	if !contains(contentStr, "func (m *FinderMock) Exists(arg0 string) (ret0 bool, err error)") {
+		t.Error("Generated mock should rename blank results")
+	}
+
+	for _, want := range []string{
+		"func (m *FinderMock) AssertFindCalled(id string) {",
+		"func (m *FinderMock) AssertFindAllCalled(limit int) {",
+		"func (m *FinderMock) AssertCountCalled() {",
+		"func (m *FinderMock) AssertExistsCalled(arg0 string) {",
+		"func (m *FinderMock) AssertTouchCalled(id string) {",
+		"func (m *FinderMock) AssertScheduleCalled(t time.Time) {",
+	} {
+		if !contains(contentStr, want) {
+			t.Errorf("Generated mock should contain a typed call assertion %q", want)
+		}
+	}
+
+	runGeneratedTests(t, dir)
+}
+
+// TestGenerateMocksChannelAndFuncReturns tests methods returning channels and functions.
+func TestGenerateMocksChannelAndFuncReturns(t *testing.T) {
+	dir := t.TempDir()
+	copyFixture(t, "channels.go", filepath.Join(dir, "subscriber.go"))
+	copyFixture(t, "channels_test.go.txt", filepath.Join(dir, "subscriber_test.go"))
+
+	gen := NewGenerator("fixture", dir)
+	if err := gen.GenerateMocks([]string{filepath.Join(dir, "subscriber.go")}); err != nil {
+		t.Fatalf("Failed to generate mock: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "subscriber_mock.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+
+	contentStr := string(content)
+	for _, want := range []string{
+		"Subscribe(topic string) (ret0 <-chan string, ret1 error)",
+		"Publisher(topic string) (ret0 chan<- string)",
+		"Handler(topic string) (ret0 func(string) error)",
+		"Handlers() (ret0 map[string]func(string) error)",
+	} {
+		if !contains(contentStr, want) {
+			t.Errorf("Generated mock should contain %q", want)
+		}
+	}
+
+	runGeneratedTests(t, dir)
+}
+
+// TestGenerateSuite tests that the suite and mock files are produced and work together.
+func TestGenerateSuite(t *testing.T) {
+	dir := t.TempDir()
+	copyFixture(t, "named_returns.go", filepath.Join(dir, "finder.go"))
+
+	gen := NewGenerator("fixture", dir)
+	if err := gen.GenerateSuite(filepath.Join(dir, "finder.go")); err != nil {
+		t.Fatalf("Failed to generate suite: %v", err)
+	}
+
+	written := gen.WrittenFiles()
+	if len(written) != 2 || filepath.Base(written[0]) != "finder_mock.go" || filepath.Base(written[1]) != "finder_suite_test.go" {
+		t.Fatalf("Expected the mock and suite files, got %v", written)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "finder_suite_test.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+
+	contentStr := string(content)
+	for _, want := range []string{
+		"func TestFinderFind(t *testing.T)",
+		"m := NewFinderMock(t)",
+		"m.OnFind(tt.id).Return(tt.wantUser, tt.wantErr)",
+		"gotUser, gotErr := m.Find(tt.id)",
+		"assert.Equal(t, tt.wantUser, gotUser)",
+		"m.OnExists(tt.arg0).Return(tt.wantRet0, tt.wantErr)",
+		"m.Touch(tt.id)",
+	} {
+		if !contains(contentStr, want) {
+			t.Errorf("Generated suite should contain %q, got:\n%s", want, contentStr)
+		}
+	}
+
+	runGeneratedTests(t, dir)
+}
+
+// TestGenerateMocksCrossPackage tests a mock generated outside the interface's
+// package that references types from the interface's package and another one.
+func TestGenerateMocksCrossPackage(t *testing.T) {
+	dir := t.TempDir()
+	for _, sub := range []string{"foo", "bar", "mocks"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", sub, err)
+		}
+	}
+	copyFixture(t, "crosspkg/repository.go", filepath.Join(dir, "foo", "repository.go"))
+	copyFixture(t, "crosspkg/bar.go.txt", filepath.Join(dir, "bar", "user.go"))
+	copyFixture(t, "crosspkg/mocks_test.go.txt", filepath.Join(dir, "mocks", "mocks_test.go"))
+	writeGoMod(t, dir)
+
+	gen := NewGenerator("mocks", filepath.Join(dir, "mocks"))
+	if err := gen.GenerateMocks([]string{filepath.Join(dir, "foo", "repository.go")}); err != nil {
+		t.Fatalf("Failed to generate mock: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "mocks", "userrepository_mock.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+
+	contentStr := string(content)
+	for _, want := range []string{
+		"package mocks",
+		"\t\"fixture/bar\"\n",
+		"\t\"fixture/foo\"\n",
+		"FindByID(id string) (ret0 *bar.User, ret1 error)",
+		"Filter(q foo.Query, limit int) (ret0 []*bar.User, ret1 error)",
+		"var _ foo.UserRepository = (*UserRepositoryMock)(nil)",
+	} {
+		if !contains(contentStr, want) {
+			t.Errorf("Generated mock should contain %q, got:\n%s", want, contentStr)
+		}
+	}
+
+	runGeneratedTests(t, dir)
+}
+
+// TestGenerateMocksSamePackage tests a test-only mock generated into the
+// interface's own package.
+func TestGenerateMocksSamePackage(t *testing.T) {
+	dir := t.TempDir()
+	for _, sub := range []string{"foo", "bar"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", sub, err)
+		}
+	}
+	copyFixture(t, "crosspkg/repository.go", filepath.Join(dir, "foo", "repository.go"))
+	copyFixture(t, "crosspkg/bar.go.txt", filepath.Join(dir, "bar", "user.go"))
+	writeGoMod(t, dir)
+
+	gen := NewGenerator("mocks", filepath.Join(dir, "foo"))
+	gen.SamePackage = true
+	if err := gen.GenerateMocks([]string{filepath.Join(dir, "foo", "repository.go")}); err != nil {
+		t.Fatalf("Failed to generate mock: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "foo", "mock_userrepository_test.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+
+	contentStr := string(content)
+	for _, want := range []string{
+		"package foo\n",
+		"Filter(q Query, limit int) (ret0 []*bar.User, ret1 error)",
+		"var _ UserRepository = (*UserRepositoryMock)(nil)",
+	} {
+		if !contains(contentStr, want) {
+			t.Errorf("Generated mock should contain %q, got:\n%s", want, contentStr)
+		}
+	}
+	if contains(contentStr, "\"fixture/foo\"") || contains(contentStr, "foo.") {
+		t.Errorf("Generated mock should not refer to its own package, got:\n%s", contentStr)
+	}
+
+	runGeneratedTests(t, dir)
+}
+
+// TestGenerateStubs tests that a stub generated into another package
+// satisfies the interface and returns zero values.
+func TestGenerateStubs(t *testing.T) {
+	dir := t.TempDir()
+	for _, sub := range []string{"foo", "bar", "stubs"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", sub, err)
+		}
+	}
+	copyFixture(t, "crosspkg/repository.go", filepath.Join(dir, "foo", "repository.go"))
+	copyFixture(t, "crosspkg/bar.go.txt", filepath.Join(dir, "bar", "user.go"))
+	copyFixture(t, "crosspkg/stubs_test.go.txt", filepath.Join(dir, "stubs", "stubs_test.go"))
+	writeGoMod(t, dir)
+
+	gen := NewGenerator("stubs", filepath.Join(dir, "stubs"))
+	if err := gen.GenerateStubs([]string{filepath.Join(dir, "foo", "repository.go")}); err != nil {
+		t.Fatalf("Failed to generate stub: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "stubs", "userrepository_stub.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+
+	contentStr := string(content)
+	for _, want := range []string{
+		"type UserRepositoryStub struct{}",
+		"func (UserRepositoryStub) FindByID(id string) (ret0 *bar.User, ret1 error) {",
+		"func (UserRepositoryStub) Save(u *bar.User) (ret0 error) {",
+	} {
+		if !contains(contentStr, want) {
+			t.Errorf("Generated stub should contain %q, got:\n%s", want, contentStr)
+		}
+	}
+	if contains(contentStr, "mock") || contains(contentStr, "\"testing\"") {
+		t.Errorf("Expected the stub not to depend on the mock or testing packages, got:\n%s", contentStr)
+	}
+
+	runGeneratedTests(t, dir)
+}
+
+// TestGenerateMocksCrossPackageUnexported tests that unexported local types are rejected.
+func TestGenerateMocksCrossPackageUnexported(t *testing.T) {
+	dir := t.TempDir()
+	source := "package foo\n\ntype query struct{}\n\ntype Finder interface {\n\tFind(q query) error\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "finder.go"), []byte(source), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+	writeGoMod(t, dir)
+
+	err := NewGenerator("mocks", filepath.Join(dir, "mocks")).GenerateMocks([]string{filepath.Join(dir, "finder.go")})
+	if err == nil || !contains(err.Error(), "type query is unexported") {
+		t.Errorf("Expected an unexported type error, got %v", err)
+	}
+}
+
+// TestGenerateMocksCrossPackageUnexportedInterface tests that no interface
+// assertion is emitted for an interface the mock's package cannot refer to.
+func TestGenerateMocksCrossPackageUnexportedInterface(t *testing.T) {
+	dir := t.TempDir()
+	source := "package foo\n\ntype finder interface {\n\tFind(id string) error\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "finder.go"), []byte(source), 0644); err != nil {
+		t.Fatalf("Failed to write source: %v", err)
+	}
+	writeGoMod(t, dir)
+
+	gen := NewGenerator("mocks", filepath.Join(dir, "mocks"))
+	if err := gen.GenerateMocks([]string{filepath.Join(dir, "finder.go")}); err != nil {
+		t.Fatalf("Failed to generate mock: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "mocks", "finder_mock.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	if contains(string(content), "var _") {
+		t.Errorf("Expected no assertion for an unexported interface, got:\n%s", content)
+	}
+}
+
+// TestGenerateMocksTestifyStyle tests mocks targeting testify's mock package.
+// testify is not a dependency of this module, so the output is only parsed.
+func TestGenerateMocksTestifyStyle(t *testing.T) {
+	dir := t.TempDir()
+	copyFixture(t, "named_returns.go", filepath.Join(dir, "finder.go"))
+
+	gen := NewGenerator("fixture", dir)
+	gen.Style = StyleTestify
+	if err := gen.GenerateMocks([]string{filepath.Join(dir, "finder.go")}); err != nil {
+		t.Fatalf("Failed to generate mock: %v", err)
+	}
+
+	mockPath := filepath.Join(dir, "finder_mock.go")
+	content, err := os.ReadFile(mockPath)
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), mockPath, content, 0); err != nil {
+		t.Fatalf("Generated mock is not valid Go: %v\n%s", err, content)
+	}
+
+	contentStr := string(content)
+	for _, want := range []string{
+		"\t\"github.com/stretchr/testify/mock\"\n",
+		"type FinderMock struct {\n\tmock.Mock\n}",
+		"_ret := m.Called(id)",
+		"if _v := _ret.Get(0); _v != nil {\n\t\tuser = _v.(*User)\n\t}",
+		"err = _ret.Error(1)",
+		"ret0 = _ret.Int(0)",
+		"_ret := m.Called(args)",
+		"\tm.Called(id)\n}",
+		"var _ Finder = (*FinderMock)(nil)",
+	} {
+		if !contains(contentStr, want) {
+			t.Errorf("Generated mock should contain %q, got:\n%s", want, contentStr)
+		}
+	}
+
+	if contains(contentStr, "GopeherKit.Test/mock") {
+		t.Error("Testify-style mock should not import this module's mock package")
+	}
+}
+
+// TestGenerateMocksCustomMockImport tests overriding the mock package import path.
+func TestGenerateMocksCustomMockImport(t *testing.T) {
+	tests := []struct {
+		name       string
+		mockImport string
+		want       string
+	}{
+		{"relocated mock package", "example.com/monorepo/third_party/gopherkit/mock", "\t\"example.com/monorepo/third_party/gopherkit/mock\"\n"},
+		{"differently named package", "example.com/monorepo/testing/mocking", "\tmock \"example.com/monorepo/testing/mocking\"\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			copyFixture(t, "named_returns.go", filepath.Join(dir, "finder.go"))
+
+			gen := NewGenerator("fixture", dir)
+			gen.MockImport = tt.mockImport
+			if err := gen.GenerateMocks([]string{filepath.Join(dir, "finder.go")}); err != nil {
+				t.Fatalf("Failed to generate mock: %v", err)
+			}
+
+			content, err := os.ReadFile(filepath.Join(dir, "finder_mock.go"))
+			if err != nil {
+				t.Fatalf("Failed to read generated file: %v", err)
+			}
+
+			contentStr := string(content)
+			if !contains(contentStr, tt.want) {
+				t.Errorf("Generated file should import %q, got:\n%s", tt.want, contentStr)
+			}
+			if contains(contentStr, "GopeherKit.Test/mock") {
+				t.Errorf("Generated file should not import the canonical mock package, got:\n%s", contentStr)
+			}
+		})
+	}
+}
+
+// TestGenerateMocksUnknownStyle tests that an unsupported style is rejected.
+func TestGenerateMocksUnknownStyle(t *testing.T) {
+	gen := NewGenerator("fixture", t.TempDir())
+	gen.Style = "gomock"
+	if err := gen.GenerateMocks([]string{"testdata/named_returns.go"}); err == nil {
+		t.Error("Expected an error for an unknown style")
+	}
+}
+
+// TestGenerateMocksSingleFile tests aggregating mocks with colliding package names.
+func TestGenerateMocksSingleFile(t *testing.T) {
+	dir := t.TempDir()
+	for _, sub := range []string{"billing/models", "users/models"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", sub, err)
+		}
+	}
+	copyFixture(t, "aggregate/orders.go", filepath.Join(dir, "orders.go"))
+	copyFixture(t, "aggregate/accounts.go", filepath.Join(dir, "accounts.go"))
+	copyFixture(t, "aggregate/billing_models.go.txt", filepath.Join(dir, "billing/models/models.go"))
+	copyFixture(t, "aggregate/users_models.go.txt", filepath.Join(dir, "users/models/models.go"))
+	copyFixture(t, "aggregate/mocks_test.go.txt", filepath.Join(dir, "mocks_test.go"))
+
+	gen := NewGenerator("fixture", dir)
+	gen.SingleFile = true
+	err := gen.GenerateMocks([]string{filepath.Join(dir, "orders.go"), filepath.Join(dir, "accounts.go")})
+	if err != nil {
+		t.Fatalf("Failed to generate mocks: %v", err)
+	}
+
+	if written := gen.WrittenFiles(); len(written) != 1 || filepath.Base(written[0]) != "mocks.go" {
+		t.Fatalf("Expected a single mocks.go, got %v", written)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "mocks.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+
+	contentStr := string(content)
+	for _, want := range []string{
+		"\t\"fixture/billing/models\"\n",
+		"\tusersmodels \"fixture/users/models\"\n",
+		"\t\"time\"\n",
+		"func (m *OrdersMock) Invoice(orderID string) (ret0 *models.Invoice, ret1 error)",
+		"func (m *AccountsMock) Lookup(id string) (ret0 usersmodels.Account, ret1 error)",
+		"var _ Orders = (*OrdersMock)(nil)\nvar _ Accounts = (*AccountsMock)(nil)\n",
+	} {
+		if !contains(contentStr, want) {
+			t.Errorf("Generated file should contain %q, got:\n%s", want, contentStr)
+		}
+	}
+
+	if strings.Count(contentStr, "\"testing\"") != 1 {
+		t.Errorf("Expected the testing import exactly once, got:\n%s", contentStr)
+	}
+
+	runGeneratedTests(t, dir)
+}
+
+// TestListInterfaces tests that interface names, method counts and files are reported.
+func TestListInterfaces(t *testing.T) {
+	path := filepath.Join("testdata", "shapes.go")
+	summaries, err := ListInterfaces(path)
+	if err != nil {
+		t.Fatalf("Failed to list interfaces: %v", err)
+	}
+
+	expected := []InterfaceSummary{
+		{Name: "Shape", Methods: 2, File: path},
+		{Name: "Named", Methods: 1, File: path},
+		{Name: "Canvas", Methods: 3, File: path},
+	}
+	if len(summaries) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, summaries)
+	}
+	for i := range expected {
+		if summaries[i] != expected[i] {
+			t.Errorf("Expected %+v, got %+v", expected[i], summaries[i])
+		}
+	}
+
+	if _, err := ListInterfaces(filepath.Join("testdata", "missing.go")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+// copyFixture copies a file from testdata to dst.
+func copyFixture(t *testing.T, name, dst string) {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("Failed to read fixture %s: %v", name, err)
+	}
+
+	if err := os.WriteFile(dst, content, 0644); err != nil {
+		t.Fatalf("Failed to write fixture %s: %v", dst, err)
+	}
+}
+
+// runGeneratedTests builds and tests the package in dir as a temporary module
+// that resolves this repository from the local checkout.
+func runGeneratedTests(t *testing.T, dir string) {
+	t.Helper()
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	writeGoMod(t, dir)
+
+	cmd := exec.Command(goBin, "test", "./...")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOPROXY=off", "GOWORK=off")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("Generated code failed to build or test: %v\n%s", err, output)
+	}
+}
+
+// writeGoMod makes dir the root of a "fixture" module that resolves this
+// repository from the local checkout.
+func writeGoMod(t *testing.T, dir string) {
+	t.Helper()
+
+	root, err := filepath.Abs("..")
+	if err != nil {
+		t.Fatalf("Failed to resolve repository root: %v", err)
+	}
+
+	goMod := fmt.Sprintf("module fixture\n\ngo 1.21\n\nrequire github.com/g-restante/GopeherKit.Test v0.0.0\n\nreplace github.com/g-restante/GopeherKit.Test => %s\n", root)
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0644); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+}
+
 // contains checks if a string contains a substring.
 func contains(haystack, needle string) bool {
 	for i := 0; i <= len(haystack)-len(needle); i++ {
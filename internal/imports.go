@@ -0,0 +1,219 @@
+package internal
+
+import (
+	"fmt"
+	"go/ast"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// importSet collects the imports of a generated file and assigns each
+// imported package a unique name, aliasing packages whose names collide.
+type importSet struct {
+	list   []ImportInfo
+	byPath map[string]string
+	byName map[string]string
+}
+
+// newImportSet creates an importSet with the imports every mock file has:
+// testing and the mock package at mockPath.
+func newImportSet(mockPath string) *importSet {
+	return &importSet{
+		byPath: map[string]string{
+			"testing": "testing",
+			mockPath:  "mock",
+		},
+		byName: map[string]string{
+			"testing": "testing",
+			"mock":    mockPath,
+		},
+	}
+}
+
+// add records an import of importPath and returns the name generated code
+// must use to refer to it. A package whose name is already taken by another
+// path is aliased by prefixing the name of its parent directory.
+func (s *importSet) add(importPath string) string {
+	return s.addNamed(importPath, packageName(importPath))
+}
+
+// addNamed is like add for a package whose name is known to be pkgName.
+func (s *importSet) addNamed(importPath, pkgName string) string {
+	if name, ok := s.byPath[importPath]; ok {
+		return name
+	}
+
+	name := pkgName
+	if _, taken := s.byName[name]; taken {
+		prefix := identifier(path.Base(path.Dir(importPath)))
+		name = prefix + pkgName
+		for i := 2; s.byName[name] != ""; i++ {
+			name = fmt.Sprintf("%s%s%d", prefix, pkgName, i)
+		}
+	}
+
+	alias := ""
+	if name != pkgName {
+		alias = name
+	}
+
+	s.byPath[importPath] = name
+	s.byName[name] = importPath
+	s.list = append(s.list, ImportInfo{Name: alias, Path: importPath})
+	return name
+}
+
+// qualifyImports rewrites the package qualifiers used in iface to the names
+// assigned by imports, adding every package iface refers to.
+func qualifyImports(iface *ast.InterfaceType, file *ast.File, imports *importSet) {
+	local := make(map[string]string)
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := packageName(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if name != "_" && name != "." {
+			local[name] = importPath
+		}
+	}
+
+	ast.Inspect(iface, func(n ast.Node) bool {
+		selector, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if ident, ok := selector.X.(*ast.Ident); ok {
+			if importPath, ok := local[ident.Name]; ok {
+				ident.Name = imports.add(importPath)
+			}
+		}
+		return false
+	})
+}
+
+// packageName guesses the package name of importPath from its last element,
+// skipping a major version suffix such as "/v2".
+func packageName(importPath string) string {
+	base := path.Base(importPath)
+	if len(base) > 1 && base[0] == 'v' && strings.Trim(base[1:], "0123456789") == "" && path.Dir(importPath) != "." {
+		base = path.Base(path.Dir(importPath))
+	}
+	return identifier(base)
+}
+
+// identifier drops the characters of s that are not valid in a Go identifier.
+func identifier(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// predeclaredTypes lists the types that never need a package qualifier.
+var predeclaredTypes = map[string]bool{
+	"any": true, "bool": true, "byte": true, "comparable": true, "complex64": true,
+	"complex128": true, "error": true, "float32": true, "float64": true, "int": true,
+	"int8": true, "int16": true, "int32": true, "int64": true, "rune": true,
+	"string": true, "uint": true, "uint8": true, "uint16": true, "uint32": true,
+	"uint64": true, "uintptr": true,
+}
+
+// qualifyLocalTypes qualifies the unqualified, non-predeclared types in the
+// method signatures of iface with pkgName, so the signatures stay valid when
+// emitted into another package. Unexported types cannot be referenced from
+// another package and are reported as an error.
+func qualifyLocalTypes(iface *ast.InterfaceType, pkgName string) error {
+	var err error
+	var qualify func(expr ast.Expr) ast.Expr
+	qualify = func(expr ast.Expr) ast.Expr {
+		switch t := expr.(type) {
+		case *ast.Ident:
+			if predeclaredTypes[t.Name] {
+				return t
+			}
+			if !ast.IsExported(t.Name) && err == nil {
+				err = fmt.Errorf("type %s is unexported and cannot be used outside package %s", t.Name, pkgName)
+			}
+			return &ast.SelectorExpr{X: ast.NewIdent(pkgName), Sel: t}
+		case *ast.StarExpr:
+			t.X = qualify(t.X)
+		case *ast.ArrayType:
+			t.Elt = qualify(t.Elt)
+		case *ast.MapType:
+			t.Key = qualify(t.Key)
+			t.Value = qualify(t.Value)
+		case *ast.ChanType:
+			t.Value = qualify(t.Value)
+		case *ast.Ellipsis:
+			t.Elt = qualify(t.Elt)
+		case *ast.FuncType:
+			qualifyFields(t.Params, qualify)
+			qualifyFields(t.Results, qualify)
+		}
+		return expr
+	}
+
+	for _, method := range iface.Methods.List {
+		if funcType, ok := method.Type.(*ast.FuncType); ok {
+			qualify(funcType)
+		}
+	}
+	return err
+}
+
+// qualifyFields applies qualify to the type of every field in fieldList.
+func qualifyFields(fieldList *ast.FieldList, qualify func(ast.Expr) ast.Expr) {
+	if fieldList == nil {
+		return
+	}
+	for _, field := range fieldList.List {
+		field.Type = qualify(field.Type)
+	}
+}
+
+// packageImportPath derives the import path of the package in dir from the
+// module path declared in the nearest go.mod above it.
+func packageImportPath(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", dir, err)
+	}
+
+	for root := dir; ; root = filepath.Dir(root) {
+		content, err := os.ReadFile(filepath.Join(root, "go.mod"))
+		if err == nil {
+			modulePath := modulePath(content)
+			if modulePath == "" {
+				return "", fmt.Errorf("no module directive in %s", filepath.Join(root, "go.mod"))
+			}
+			rel, err := filepath.Rel(root, dir)
+			if err != nil {
+				return "", err
+			}
+			return path.Join(modulePath, filepath.ToSlash(rel)), nil
+		}
+		if filepath.Dir(root) == root {
+			return "", fmt.Errorf("no go.mod found above %s", dir)
+		}
+	}
+}
+
+// modulePath returns the module path declared in the contents of a go.mod file.
+func modulePath(goMod []byte) string {
+	for _, line := range strings.Split(string(goMod), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,83 @@
+package internal
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// InterfaceSummary describes an interface declared in a Go source file.
+type InterfaceSummary struct {
+	Name    string
+	Methods int
+	File    string
+}
+
+// ListInterfaces returns the interfaces declared in the Go file at path, or in
+// the non-test Go files of the directory at path, in file and source order.
+// Methods counts the methods declared directly in the interface; embedded
+// interfaces are not expanded.
+func ListInterfaces(path string) ([]InterfaceSummary, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		files = nil
+		for _, entry := range entries {
+			name := entry.Name()
+			if !entry.IsDir() && strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go") {
+				files = append(files, filepath.Join(path, name))
+			}
+		}
+		sort.Strings(files)
+	}
+
+	var summaries []InterfaceSummary
+	fset := token.NewFileSet()
+	for _, file := range files {
+		parsed, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+
+		ast.Inspect(parsed, func(n ast.Node) bool {
+			spec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			if iface, ok := spec.Type.(*ast.InterfaceType); ok {
+				summaries = append(summaries, InterfaceSummary{
+					Name:    spec.Name.Name,
+					Methods: countMethods(iface),
+					File:    file,
+				})
+			}
+			return false
+		})
+	}
+
+	return summaries, nil
+}
+
+// countMethods counts the methods declared directly in iface.
+func countMethods(iface *ast.InterfaceType) int {
+	count := 0
+	for _, field := range iface.Methods.List {
+		if _, ok := field.Type.(*ast.FuncType); ok {
+			count += len(field.Names)
+		}
+	}
+	return count
+}
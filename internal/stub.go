@@ -0,0 +1,72 @@
+package internal
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// StubFileInfo represents a generated file holding a no-op implementation of
+// an interface.
+type StubFileInfo struct {
+	Package string
+	Imports []ImportInfo
+	Stub    *InterfaceInfo
+}
+
+const stubTemplate = `// Code generated by GopherKit.Test; DO NOT EDIT.
+
+package {{.Package}}
+{{if .Imports}}
+import (
+{{- range .Imports}}
+	{{if .Name}}{{.Name}} {{end}}"{{.Path}}"
+{{- end}}
+)
+{{end}}
+{{- with .Stub}}
+// {{.Name}}Stub is a no-op implementation of {{.Name}}.
+// Every method returns zero values.
+type {{.Name}}Stub struct{}
+{{range .Methods}}
+// {{.Name}} returns zero values.
+func ({{$.Stub.Name}}Stub) {{.Name}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{.Name}} {{.Type}}{{end}}){{if .Returns}} ({{range $i, $r := .Returns}}{{if $i}}, {{end}}{{.Name}} {{.Type}}{{end}}){{end}} {
+	{{- if .Returns}}
+	return
+	{{- end}}
+}
+{{end}}
+{{- end}}`
+
+// GenerateStubs writes a <name>_stub.go file for each interface file, holding
+// a struct whose methods do nothing and return zero values. Unlike the mocks
+// from GenerateMocks, stubs record nothing and need no *testing.T.
+func (g *Generator) GenerateStubs(interfaces []string) error {
+	tmpl, err := template.New("stub").Parse(stubTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse stub template: %w", err)
+	}
+
+	for _, interfacePath := range interfaces {
+		// Stubs import nothing of their own, so no names are reserved.
+		imports := &importSet{byPath: map[string]string{}, byName: map[string]string{}}
+		interfaceInfo, err := g.parseInterface(interfacePath, imports)
+		if err != nil {
+			return fmt.Errorf("failed to parse interface %s: %w", interfacePath, err)
+		}
+
+		var buf strings.Builder
+		stub := &StubFileInfo{Package: interfaceInfo.Package, Imports: imports.list, Stub: interfaceInfo}
+		if err := tmpl.Execute(&buf, stub); err != nil {
+			return fmt.Errorf("failed to execute stub template: %w", err)
+		}
+
+		outputPath := filepath.Join(g.OutputDir, strings.ToLower(interfaceInfo.Name)+"_stub.go")
+		if err := g.writeFile(outputPath, buf.String()); err != nil {
+			return fmt.Errorf("failed to write stub file %s: %w", outputPath, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package internal
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// SuiteInfo represents a generated test file exercising the mock of an interface.
+type SuiteInfo struct {
+	Package    string
+	Name       string
+	Imports    []ImportInfo
+	HasReturns bool
+	Methods    []SuiteMethodInfo
+}
+
+// SuiteMethodInfo represents the table-driven test of a single method.
+type SuiteMethodInfo struct {
+	Name    string
+	Fields  []SuiteFieldInfo
+	Args    []string
+	Wants   []SuiteFieldInfo
+	Results []string
+}
+
+// SuiteFieldInfo represents a field of a test case struct.
+type SuiteFieldInfo struct {
+	Field string
+	Type  string
+}
+
+const suiteTemplate = `// Code generated by GopherKit.Test. Fill in the test cases and replace the
+// direct mock calls with calls to the code under test.
+
+package {{.Package}}
+
+import (
+	"testing"
+{{- if .HasReturns}}
+	"github.com/g-restante/GopeherKit.Test/assert"
+{{- end}}
+{{- range .Imports}}
+	{{if .Name}}{{.Name}} {{end}}"{{.Path}}"
+{{- end}}
+)
+{{range $method := .Methods}}
+// Test{{$.Name}}{{.Name}} is a table-driven skeleton for {{$.Name}}.{{.Name}}.
+func Test{{$.Name}}{{.Name}}(t *testing.T) {
+	tests := []struct {
+		name string
+		{{- range .Fields}}
+		{{.Field}} {{.Type}}
+		{{- end}}
+		{{- range .Wants}}
+		{{.Field}} {{.Type}}
+		{{- end}}
+	}{
+		// TODO: add test cases.
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New{{$.Name}}Mock(t)
+			m.On{{.Name}}({{join .Args ", "}}).Return({{range $i, $w := .Wants}}{{if $i}}, {{end}}tt.{{.Field}}{{end}})
+
+			{{if .Results}}{{join .Results ", "}} := {{end}}m.{{.Name}}({{join .Args ", "}})
+			{{- range $i, $w := .Wants}}
+			assert.Equal(t, tt.{{.Field}}, {{index $method.Results $i}})
+			{{- end}}
+			m.AssertExpectations()
+		})
+	}
+}
+{{end}}`
+
+// GenerateSuite generates the mock for the interface in interfacePath with
+// GenerateMocks, plus a <name>_suite_test.go file holding a table-driven test
+// skeleton for each method that creates the mock through its constructor.
+func (g *Generator) GenerateSuite(interfacePath string) error {
+	if g.Style == StyleTestify {
+		return fmt.Errorf("test suites can only be generated for the default mock style")
+	}
+
+	if err := g.GenerateMocks([]string{interfacePath}); err != nil {
+		return err
+	}
+
+	imports := newImportSet(g.mockImportPath())
+	interfaceInfo, err := g.parseInterface(interfacePath, imports)
+	if err != nil {
+		return fmt.Errorf("failed to parse interface %s: %w", interfacePath, err)
+	}
+
+	suite := &SuiteInfo{
+		Package: interfaceInfo.Package,
+		Name:    interfaceInfo.Name,
+		Imports: imports.list,
+	}
+	for _, method := range interfaceInfo.Methods {
+		suite.Methods = append(suite.Methods, suiteMethod(method))
+		if len(method.Returns) > 0 {
+			suite.HasReturns = true
+		}
+	}
+
+	tmpl, err := template.New("suite").Funcs(template.FuncMap{"join": strings.Join}).Parse(suiteTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to parse suite template: %w", err)
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, suite); err != nil {
+		return fmt.Errorf("failed to execute suite template: %w", err)
+	}
+
+	outputPath := filepath.Join(g.OutputDir, strings.ToLower(interfaceInfo.Name)+"_suite_test.go")
+	if err := g.writeFile(outputPath, buf.String()); err != nil {
+		return fmt.Errorf("failed to write suite file %s: %w", outputPath, err)
+	}
+	return nil
+}
+
+// suiteMethod derives the test case fields, call arguments and result
+// variables for a method. Parameters become fields named after them, results
+// become want fields and got variables.
+func suiteMethod(method MethodInfo) SuiteMethodInfo {
+	info := SuiteMethodInfo{Name: method.Name}
+
+	for _, param := range method.Params {
+		field, arg := param.Name, "tt."+param.Name
+		if field == "name" {
+			field = "nameArg"
+			arg = "tt.nameArg"
+		}
+
+		fieldType := param.Type
+		if strings.HasPrefix(fieldType, "...") {
+			fieldType = "[]" + strings.TrimPrefix(fieldType, "...")
+			arg += "..."
+		}
+
+		info.Fields = append(info.Fields, SuiteFieldInfo{Field: field, Type: fieldType})
+		info.Args = append(info.Args, arg)
+	}
+
+	for _, result := range method.Returns {
+		suffix := strings.ToUpper(result.Name[:1]) + result.Name[1:]
+		info.Wants = append(info.Wants, SuiteFieldInfo{Field: "want" + suffix, Type: result.Type})
+		info.Results = append(info.Results, "got"+suffix)
+	}
+
+	return info
+}
@@ -0,0 +1,14 @@
+package fixture
+
+import (
+	"time"
+
+	"fixture/users/models"
+)
+
+// Accounts references models from the users package, whose name collides
+// with the billing package used by Orders.
+type Accounts interface {
+	Lookup(id string) (models.Account, error)
+	Touch(id string, at time.Time)
+}
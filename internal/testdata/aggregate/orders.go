@@ -0,0 +1,8 @@
+package fixture
+
+import "fixture/billing/models"
+
+// Orders references models from the billing package.
+type Orders interface {
+	Invoice(orderID string) (*models.Invoice, error)
+}
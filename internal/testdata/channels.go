@@ -0,0 +1,9 @@
+package fixture
+
+// Subscriber returns channels and functions from its methods.
+type Subscriber interface {
+	Subscribe(topic string) (<-chan string, error)
+	Publisher(topic string) chan<- string
+	Handler(topic string) func(event string) error
+	Handlers() map[string]func(string) error
+}
@@ -0,0 +1,16 @@
+package foo
+
+import "fixture/bar"
+
+// Query is declared next to the interface and must be qualified as foo.Query
+// in a mock generated into another package.
+type Query struct {
+	Name string
+}
+
+// UserRepository returns types from package bar.
+type UserRepository interface {
+	FindByID(id string) (*bar.User, error)
+	Filter(q Query, limit int) ([]*bar.User, error)
+	Save(u *bar.User) error
+}
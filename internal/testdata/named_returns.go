@@ -0,0 +1,20 @@
+package fixture
+
+import "time"
+
+// User is a domain type referenced by the fixture interface.
+type User struct {
+	ID   string
+	Name string
+}
+
+// Finder mixes named, unnamed and blank results.
+type Finder interface {
+	Find(id string) (user *User, err error)
+	FindAll(limit int) (users []*User, total int, err error)
+	Count() int
+	Exists(string) (_ bool, err error)
+	Touch(id string)
+	Schedule(t time.Time) error
+	Run(args []string) (code int, err error)
+}
@@ -0,0 +1,20 @@
+package fixture
+
+// Shape is implemented by every drawable figure.
+type Shape interface {
+	Area() float64
+	Perimeter() float64
+}
+
+// Named is embedded to check that only declared methods are counted.
+type Named interface {
+	Name() string
+}
+
+// Canvas draws shapes.
+type Canvas interface {
+	Named
+	Draw(s Shape) error
+	Clear()
+	Size() (width, height int)
+}
@@ -0,0 +1,97 @@
+package internal
+
+import "fmt"
+
+// testifyMockPath is the import path of testify's mock package.
+const testifyMockPath = "github.com/stretchr/testify/mock"
+
+// Templates for mocks in the style of github.com/stretchr/testify/mock.
+const (
+	testifyFileTemplate = `// Code generated by GopherKit.Test; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	{{with .MockImport}}{{if .Name}}{{.Name}} {{end}}"{{.Path}}"{{end}}
+{{- range .Imports}}
+	{{if .Name}}{{.Name}} {{end}}"{{.Path}}"
+{{- end}}
+)
+{{template "assertions" .}}
+{{- range .Mocks}}{{template "mock" .}}{{end}}`
+
+	testifyMockTemplate = `
+// {{.Name}}Mock is a testify mock implementation of {{.Name}}.
+type {{.Name}}Mock struct {
+	mock.Mock
+}
+
+// New{{.Name}}Mock creates a new mock for {{.Name}} that asserts its
+// expectations when the test finishes.
+func New{{.Name}}Mock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *{{.Name}}Mock {
+	m := &{{.Name}}Mock{}
+	m.Mock.Test(t)
+	t.Cleanup(func() { m.AssertExpectations(t) })
+	return m
+}
+{{range .Methods}}
+// {{.Name}} is a mock implementation of the {{.Name}} method.
+func (m *{{$.Name}}Mock) {{.Name}}({{range $i, $p := .Params}}{{if $i}}, {{end}}{{.Name}} {{.Type}}{{end}}){{if .Returns}} ({{range $i, $r := .Returns}}{{if $i}}, {{end}}{{.Name}} {{.Type}}{{end}}){{end}} {
+	{{if .Returns}}_ret := {{end}}m.Called({{range $i, $p := .Params}}{{if $i}}, {{end}}{{.Name}}{{end}})
+	{{- range $i, $r := .Returns}}
+	{{- with testifyGetter .Type $i}}
+	{{$r.Name}} = {{.}}
+	{{- else}}
+	if _v := _ret.Get({{$i}}); _v != nil {
+		{{$r.Name}} = _v.({{$r.Type}})
+	}
+	{{- end}}
+	{{- end}}
+	{{- if .Returns}}
+	return
+	{{- end}}
+}
+{{end}}`
+)
+
+// mockImportPath returns the import path of the mock package generated code
+// is built on.
+func (g *Generator) mockImportPath() string {
+	if g.MockImport != "" {
+		return g.MockImport
+	}
+	if g.Style == StyleTestify {
+		return testifyMockPath
+	}
+	return "github.com/g-restante/GopeherKit.Test/mock"
+}
+
+// mockImport returns the import of the mock package, aliased to mock when the
+// package at the import path has another name.
+func (g *Generator) mockImport() ImportInfo {
+	importPath := g.mockImportPath()
+	if packageName(importPath) != "mock" {
+		return ImportInfo{Name: "mock", Path: importPath}
+	}
+	return ImportInfo{Path: importPath}
+}
+
+// testifyGetter returns the typed testify accessor for result i, such as
+// _ret.Error(1), or an empty string when the result needs _ret.Get and a
+// type assertion.
+func testifyGetter(resultType string, i int) string {
+	switch resultType {
+	case "error":
+		return fmt.Sprintf("_ret.Error(%d)", i)
+	case "string":
+		return fmt.Sprintf("_ret.String(%d)", i)
+	case "int":
+		return fmt.Sprintf("_ret.Int(%d)", i)
+	case "bool":
+		return fmt.Sprintf("_ret.Bool(%d)", i)
+	}
+	return ""
+}
@@ -0,0 +1,126 @@
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// FuncMock routes calls to a function value through a Mock, so function-typed
+// fields can be stubbed and verified like interface methods.
+type FuncMock[F any] struct {
+	mock *Mock
+	name string
+}
+
+// Func creates a FuncMock of function type F whose calls are recorded on m
+// under name.
+//
+//	f := mock.Func[func(string) error](m, "callback")
+//	f.On("123").Return(nil)
+//	obj.Callback = f.AsFunc()
+func Func[F any](m *Mock, name string) *FuncMock[F] {
+	if reflect.TypeOf((*F)(nil)).Elem().Kind() != reflect.Func {
+		panic(fmt.Sprintf("mock.Func: %s is not a function type", reflect.TypeOf((*F)(nil)).Elem()))
+	}
+	return &FuncMock[F]{mock: m, name: name}
+}
+
+// On sets up an expectation for a call to the function with the given arguments.
+func (f *FuncMock[F]) On(args ...any) *Call {
+	return f.mock.On(f.name, args...)
+}
+
+// AsFunc returns a function of type F that records each call through
+// Mock.Called and returns the configured values.
+func (f *FuncMock[F]) AsFunc() F {
+	fnType := reflect.TypeOf((*F)(nil)).Elem()
+
+	fn := reflect.MakeFunc(fnType, func(in []reflect.Value) []reflect.Value {
+		args := make([]any, len(in))
+		for i, arg := range in {
+			args[i] = arg.Interface()
+		}
+		return returnValues(fnType, f.name, f.mock.Called(f.name, args...))
+	})
+
+	return fn.Interface().(F)
+}
+
+// returnValues converts the configured return values to the result types of
+// fnType. Missing or nil values become the zero value of their result type.
+// It panics if a value does not fit its result type.
+func returnValues(fnType reflect.Type, method string, results []any) []reflect.Value {
+	values := make([]reflect.Value, fnType.NumOut())
+	for i := range values {
+		value, err := returnValue(fnType.Out(i), results, i)
+		if err != nil {
+			panic(fmt.Sprintf("mock: %s: %v", method, err))
+		}
+		values[i] = value
+	}
+	return values
+}
+
+var rawMessageType = reflect.TypeOf(json.RawMessage(nil))
+
+// returnValue converts results[i] to out. A missing or nil value becomes the
+// zero value of out, and a json.RawMessage loaded by LoadFromJSON is decoded
+// into out. Other values must be assignable to out; the only conversions made
+// are from a bidirectional channel to a directional one and between function
+// types with the same signature, so that a misconfigured Return such as an int
+// for a string is reported instead of silently converted.
+func returnValue(out reflect.Type, results []any, i int) (reflect.Value, error) {
+	if i >= len(results) || results[i] == nil {
+		return reflect.Zero(out), nil
+	}
+
+	if raw, ok := results[i].(json.RawMessage); ok && out != rawMessageType {
+		decoded := reflect.New(out)
+		if err := json.Unmarshal(raw, decoded.Interface()); err != nil {
+			return reflect.Value{}, fmt.Errorf("recorded return value %d cannot be decoded as %s: %v", i, out, err)
+		}
+		return decoded.Elem(), nil
+	}
+
+	v := reflect.ValueOf(results[i])
+	switch {
+	case v.Type().AssignableTo(out):
+		converted := reflect.New(out).Elem()
+		converted.Set(v)
+		return converted, nil
+	case convertibleResult(v.Type(), out):
+		return v.Convert(out), nil
+	default:
+		return reflect.Value{}, fmt.Errorf("return value %d of type %s is not assignable to %s", i, v.Type(), out)
+	}
+}
+
+// convertibleResult reports whether a return value of type from may be
+// converted to the result type out: a bidirectional channel to a channel of
+// the same element type, or a function to a function type with the same
+// signature.
+func convertibleResult(from, out reflect.Type) bool {
+	switch {
+	case from.Kind() == reflect.Chan && out.Kind() == reflect.Chan:
+		return from.ChanDir() == reflect.BothDir && from.Elem() == out.Elem()
+	case from.Kind() == reflect.Func && out.Kind() == reflect.Func:
+		return from.ConvertibleTo(out)
+	}
+	return false
+}
+
+// ResultAs returns the i-th value returned by Called as a T. Unlike a type
+// assertion it accepts a bidirectional channel for <-chan T and a func literal
+// for a named function type. Missing or nil values yield the zero value of T,
+// and values replayed from LoadFromJSON are decoded into T. It panics, naming
+// the calling mock method, if the value does not fit T.
+func ResultAs[T any](results []any, i int) T {
+	var result T
+	value, err := returnValue(reflect.TypeOf(&result).Elem(), results, i)
+	if err != nil {
+		panic(fmt.Sprintf("mock: %s: %v", callerMethod(1), err))
+	}
+	reflect.ValueOf(&result).Elem().Set(value)
+	return result
+}
@@ -0,0 +1,106 @@
+package mock
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// notifier is a dependency with a function-typed field instead of an interface.
+type notifier struct {
+	Send func(to string, body string) error
+}
+
+// TestFuncAsField tests wiring a function field through the mock.
+func TestFuncAsField(t *testing.T) {
+	m := NewMock(t)
+	send := Func[func(string, string) error](m, "Send")
+
+	sendErr := errors.New("smtp down")
+	send.On("john@example.com", Any).Return(nil)
+	send.On("jane@example.com", Any).Return(sendErr)
+
+	n := notifier{Send: send.AsFunc()}
+
+	if err := n.Send("john@example.com", "hello"); err != nil {
+		t.Errorf("Expected nil error, got %v", err)
+	}
+
+	if err := n.Send("jane@example.com", "hello"); err != sendErr {
+		t.Errorf("Expected configured error, got %v", err)
+	}
+
+	if got := m.GetCallCount("Send"); got != 2 {
+		t.Errorf("Expected 2 calls to Send, got %d", got)
+	}
+
+	m.AssertCalled("Send", "john@example.com", "hello")
+	m.AssertExpectations()
+}
+
+// TestFuncRejectsNonFunctionType tests that a non-function type parameter panics.
+func TestFuncRejectsNonFunctionType(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected Func to panic for a non-function type")
+		}
+	}()
+
+	Func[string](NewMock(t), "value")
+}
+
+// TestResultAsChannelAndFunc tests converting channel and function return values.
+func TestResultAsChannelAndFunc(t *testing.T) {
+	type handler func(string) error
+
+	m := NewMock(t)
+	events := make(chan string, 1)
+	events <- "user.created"
+	m.On("Subscribe", "users").Return(events, func(topic string) error { return nil })
+
+	results := m.Called("Subscribe", "users")
+
+	ch := ResultAs[<-chan string](results, 0)
+	if ch == nil {
+		t.Fatal("Expected the configured channel as a receive-only channel")
+	}
+	if event := <-ch; event != "user.created" {
+		t.Errorf("Expected event from configured channel, got %q", event)
+	}
+
+	if h := ResultAs[handler](results, 1); h == nil || h("users") != nil {
+		t.Error("Expected the configured func converted to the named function type")
+	}
+
+	if err := ResultAs[error](results, 2); err != nil {
+		t.Errorf("Expected zero value for a missing result, got %v", err)
+	}
+}
+
+// TestReturnValueRejectsConversions tests that values which would only fit
+// through a lossy conversion are reported with the method and both types.
+func TestReturnValueRejectsConversions(t *testing.T) {
+	expectPanic := func(want string, fn func()) {
+		t.Helper()
+		defer func() {
+			t.Helper()
+			if r := recover(); r == nil || !strings.Contains(r.(string), want) {
+				t.Errorf("Expected a panic containing %q, got %v", want, r)
+			}
+		}()
+		fn()
+	}
+
+	m := NewMock(t)
+	label := Func[func() string](m, "Label")
+	label.On().Return(65)
+	expectPanic("mock: Label: return value 0 of type int is not assignable to string", func() {
+		label.AsFunc()()
+	})
+
+	m.On("Ratio").Return(2.5)
+	results := m.Called("Ratio")
+	expectPanic("mock: TestReturnValueRejectsConversions: return value 0 of type float64 is not assignable to int", func() {
+		ResultAs[int](results, 0)
+	})
+}
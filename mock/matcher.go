@@ -0,0 +1,267 @@
+package mock
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/g-restante/GopeherKit.Test/assert"
+)
+
+// UnorderedElements matches a slice or array argument containing the same
+// elements as expected, with the same multiplicity, in any order.
+func UnorderedElements(expected ...any) Matcher {
+	return &unorderedMatcher{expected: expected}
+}
+
+type unorderedMatcher struct {
+	expected []any
+}
+
+func (u *unorderedMatcher) Matches(actual any) bool {
+	v := reflect.ValueOf(actual)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return false
+	}
+
+	elems := make([]any, v.Len())
+	for i := range elems {
+		elems[i] = v.Index(i).Interface()
+	}
+
+	var probe silentT
+	assert.ElementsMatch(&probe, u.expected, elems)
+	return !probe.failed
+}
+
+func (u *unorderedMatcher) String() string {
+	return fmt.Sprintf("mock.UnorderedElements(%v)", u.expected)
+}
+
+// Deref matches a non-nil pointer argument whose pointed-to value is deeply
+// equal to expected, so a *User argument can be matched by its User value
+// without holding the exact pointer.
+func Deref(expected any) Matcher {
+	return &derefMatcher{expected: expected}
+}
+
+type derefMatcher struct {
+	expected any
+}
+
+func (d *derefMatcher) Matches(actual any) bool {
+	v := reflect.ValueOf(actual)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return false
+	}
+	return reflect.DeepEqual(d.expected, v.Elem().Interface())
+}
+
+func (d *derefMatcher) String() string {
+	return fmt.Sprintf("mock.Deref(%v)", d.expected)
+}
+
+// OneOf matches an argument deeply equal to any of values. Values that are
+// themselves matchers are applied to the argument.
+func OneOf(values ...any) Matcher {
+	return &oneOfMatcher{values: values}
+}
+
+type oneOfMatcher struct {
+	values []any
+}
+
+func (o *oneOfMatcher) Matches(actual any) bool {
+	for _, value := range o.values {
+		if matchArg(value, actual) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *oneOfMatcher) String() string {
+	return fmt.Sprintf("mock.OneOf(%v)", o.values)
+}
+
+// Not matches an argument that expected does not match. expected may be a
+// plain value, compared with reflect.DeepEqual, or another Matcher.
+//
+//	m.On("FindByID", mock.Not("123"))
+func Not(expected any) Matcher {
+	return &notMatcher{expected: expected}
+}
+
+type notMatcher struct {
+	expected any
+}
+
+func (n *notMatcher) Matches(actual any) bool {
+	return !matchArg(n.expected, actual)
+}
+
+func (n *notMatcher) String() string {
+	return fmt.Sprintf("mock.Not(%v)", n.expected)
+}
+
+// MatchedBy matches an argument for which fn returns true. fn must be a
+// function taking one argument and returning bool; arguments not assignable to
+// its parameter type never match. Several MatchedBy expectations on the same
+// method can return different values, since Called uses the first one that
+// matches:
+//
+//	m.On("FindByID", mock.MatchedBy(func(id string) bool { return id == "123" })).Return(alice, nil)
+//	m.On("FindByID", mock.MatchedBy(func(id string) bool { return id != "123" })).Return(bob, nil)
+func MatchedBy(fn any) Matcher {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func || v.Type().NumIn() != 1 || v.Type().NumOut() != 1 || v.Type().Out(0).Kind() != reflect.Bool {
+		panic(fmt.Sprintf("mock.MatchedBy expects a func(T) bool, got %T", fn))
+	}
+	return &matchedByMatcher{fn: v}
+}
+
+type matchedByMatcher struct {
+	fn reflect.Value
+}
+
+func (m *matchedByMatcher) Matches(actual any) bool {
+	in := m.fn.Type().In(0)
+
+	var arg reflect.Value
+	switch {
+	case actual == nil:
+		if !isNillable(in.Kind()) {
+			return false
+		}
+		arg = reflect.Zero(in)
+	case reflect.TypeOf(actual).AssignableTo(in):
+		arg = reflect.ValueOf(actual)
+	default:
+		return false
+	}
+	return m.fn.Call([]reflect.Value{arg})[0].Bool()
+}
+
+func (m *matchedByMatcher) String() string {
+	return fmt.Sprintf("mock.MatchedBy(%s)", m.fn.Type())
+}
+
+// And matches an argument that all of matchers match. Each entry may be a
+// Matcher or a plain value, compared with reflect.DeepEqual:
+//
+//	m.On("Save", mock.And(
+//		mock.AnythingOfType("*example.User"),
+//		mock.MatchedBy(func(u *User) bool { return u.Email != "" }),
+//	))
+func And(matchers ...any) Matcher {
+	return &andMatcher{matchers: matchers}
+}
+
+type andMatcher struct {
+	matchers []any
+}
+
+func (a *andMatcher) Matches(actual any) bool {
+	for _, matcher := range a.matchers {
+		if !matchArg(matcher, actual) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *andMatcher) String() string {
+	return fmt.Sprintf("mock.And(%v)", a.matchers)
+}
+
+// Or matches an argument that at least one of matchers matches. Like OneOf,
+// entries may be matchers or plain values.
+func Or(matchers ...any) Matcher {
+	return &orMatcher{matchers: matchers}
+}
+
+type orMatcher struct {
+	matchers []any
+}
+
+func (o *orMatcher) Matches(actual any) bool {
+	for _, matcher := range o.matchers {
+		if matchArg(matcher, actual) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *orMatcher) String() string {
+	return fmt.Sprintf("mock.Or(%v)", o.matchers)
+}
+
+// AnythingOfType matches an argument whose dynamic type, as printed by %T, is
+// typeName, such as "string" or "*example.User".
+func AnythingOfType(typeName string) Matcher {
+	return &typeMatcher{typeName: typeName}
+}
+
+type typeMatcher struct {
+	typeName string
+}
+
+func (m *typeMatcher) Matches(actual any) bool {
+	return fmt.Sprintf("%T", actual) == m.typeName
+}
+
+func (m *typeMatcher) String() string {
+	return fmt.Sprintf("mock.AnythingOfType(%q)", m.typeName)
+}
+
+// MatchesRegex matches a string argument, or one of a named string type,
+// that contains a match of pattern. It panics if pattern does not compile, so
+// a typo is reported where the expectation is set rather than as a missing
+// call:
+//
+//	m.On("SendWelcome", mock.MatchesRegex(`.*@example\.com$`))
+func MatchesRegex(pattern string) Matcher {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		panic(fmt.Sprintf("mock.MatchesRegex: invalid pattern %q: %v", pattern, err))
+	}
+	return &regexMatcher{re: re}
+}
+
+type regexMatcher struct {
+	re *regexp.Regexp
+}
+
+func (r *regexMatcher) Matches(actual any) bool {
+	v := reflect.ValueOf(actual)
+	if v.Kind() != reflect.String {
+		return false
+	}
+	return r.re.MatchString(v.String())
+}
+
+func (r *regexMatcher) String() string {
+	return fmt.Sprintf("mock.MatchesRegex(%q)", r.re.String())
+}
+
+// matchArg reports whether actual matches expected, applying expected if it
+// is a Matcher and comparing with reflect.DeepEqual otherwise.
+func matchArg(expected, actual any) bool {
+	if matcher, ok := expected.(Matcher); ok {
+		return matcher.Matches(actual)
+	}
+	return reflect.DeepEqual(expected, actual)
+}
+
+// silentT records whether an assertion failed without reporting it, so that
+// assert helpers can be reused as predicates.
+type silentT struct {
+	failed bool
+}
+
+func (s *silentT) Helper() {}
+
+func (s *silentT) Errorf(format string, args ...any) {
+	s.failed = true
+}
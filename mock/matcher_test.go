@@ -0,0 +1,204 @@
+package mock
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestUnorderedElements tests that slice arguments match regardless of order.
+func TestUnorderedElements(t *testing.T) {
+	m := NewMock(t)
+	m.On("DeleteUsers", UnorderedElements("1", "2", "3")).Return(nil)
+
+	results := m.Called("DeleteUsers", []string{"3", "1", "2"})
+	if len(results) != 1 || results[0] != nil {
+		t.Errorf("Expected configured return for reordered slice, got %v", results)
+	}
+
+	m.AssertExpectations()
+}
+
+// TestUnorderedElementsRejectsDifferentMultiset tests mismatching elements and counts.
+func TestUnorderedElementsRejectsDifferentMultiset(t *testing.T) {
+	matcher := UnorderedElements("1", "2", "2")
+
+	cases := []any{
+		[]string{"1", "2"},
+		[]string{"1", "2", "3"},
+		[]string{"1", "1", "2"},
+		"1,2,2",
+		nil,
+	}
+	for _, actual := range cases {
+		if matcher.Matches(actual) {
+			t.Errorf("Expected %#v not to match %s", actual, matcher)
+		}
+	}
+
+	if !matcher.Matches([3]string{"2", "1", "2"}) {
+		t.Error("Expected an array with the same elements to match")
+	}
+}
+
+// TestDeref tests matching a pointer argument by the value it points to.
+func TestDeref(t *testing.T) {
+	m := NewMock(t)
+	m.On("Save", Deref(user{ID: "1", Name: "Alice"})).Return(nil)
+
+	results := m.Called("Save", &user{ID: "1", Name: "Alice"})
+	if len(results) != 1 || results[0] != nil {
+		t.Errorf("Expected a fresh pointer to an equal user to match, got %v", results)
+	}
+
+	matcher := Deref(user{ID: "1", Name: "Alice"})
+	for _, actual := range []any{&user{ID: "1", Name: "Bob"}, user{ID: "1", Name: "Alice"}, (*user)(nil), nil} {
+		if matcher.Matches(actual) {
+			t.Errorf("Expected %#v not to match %s", actual, matcher)
+		}
+	}
+
+	m.AssertExpectations()
+}
+
+// TestOneOf tests that one expectation matches several argument values.
+func TestOneOf(t *testing.T) {
+	rec := &recordingT{}
+	m := NewMock(rec)
+	m.On("FindByID", OneOf("123", "456")).Return(&user{ID: "found"}, nil)
+
+	for _, id := range []string{"123", "456"} {
+		results := m.Called("FindByID", id)
+		if len(results) != 2 || results[0].(*user).ID != "found" {
+			t.Errorf("Expected FindByID(%q) to match, got %v", id, results)
+		}
+	}
+
+	m.Called("FindByID", "789")
+	if len(rec.errors) != 1 {
+		t.Errorf("Expected only the unlisted id to be unexpected, got %v", rec.errors)
+	}
+
+	if count := m.GetCallCount("FindByID"); count != 2 {
+		t.Errorf("Expected 2 matched calls, got %d", count)
+	}
+}
+
+// TestNot tests that Not excludes a value and composes with other matchers.
+func TestNot(t *testing.T) {
+	rec := &recordingT{}
+	m := NewMock(rec)
+	m.On("FindByID", Not("123")).Return(&user{ID: "other"}, nil)
+
+	results := m.Called("FindByID", "456")
+	if len(results) != 2 || results[0].(*user).ID != "other" {
+		t.Errorf("Expected a different id to match, got %v", results)
+	}
+
+	m.Called("FindByID", "123")
+	if len(rec.errors) != 1 || !strings.Contains(rec.errors[0], "Unexpected call to FindByID") {
+		t.Errorf("Expected the excluded id to be rejected, got %v", rec.errors)
+	}
+
+	if Not(OneOf("1", "2")).Matches("2") || !Not(OneOf("1", "2")).Matches("3") {
+		t.Error("Expected Not to negate a wrapped matcher")
+	}
+}
+
+// TestMatchedByDifferentReturns tests two MatchedBy expectations on one method
+// resolving to different users.
+func TestMatchedByDifferentReturns(t *testing.T) {
+	alice, bob := &user{ID: "123", Name: "Alice"}, &user{ID: "456", Name: "Bob"}
+
+	m := NewMock(t)
+	m.On("FindByID", MatchedBy(func(id string) bool { return id == "123" })).Return(alice, nil)
+	m.On("FindByID", MatchedBy(func(id string) bool { return strings.HasPrefix(id, "4") })).Return(bob, nil)
+
+	if got := m.Called("FindByID", "123")[0]; got != alice {
+		t.Errorf("Expected alice for id 123, got %v", got)
+	}
+	if got := m.Called("FindByID", "456")[0]; got != bob {
+		t.Errorf("Expected bob for id 456, got %v", got)
+	}
+	m.AssertExpectations()
+
+	rec := &recordingT{}
+	other := NewMock(rec)
+	other.On("FindByID", MatchedBy(func(id string) bool { return true })).Return(alice, nil)
+	other.Called("FindByID", 123)
+	if len(rec.errors) != 1 {
+		t.Errorf("Expected an argument of the wrong type not to match, got %v", rec.errors)
+	}
+}
+
+// TestMatchedByRejectsInvalidFunc tests that a non-predicate panics.
+func TestMatchedByRejectsInvalidFunc(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "expects a func(T) bool") {
+			t.Errorf("Expected a panic for an invalid predicate, got %v", r)
+		}
+	}()
+	MatchedBy(func(id string) string { return id })
+}
+
+// TestMatchesRegex tests matching an email argument against a domain pattern.
+func TestMatchesRegex(t *testing.T) {
+	m := NewMock(t)
+	m.On("SendWelcome", MatchesRegex(`.*@example\.com`)).Return(nil)
+
+	m.Called("SendWelcome", "alice@example.com")
+	m.AssertExpectations()
+
+	rec := &recordingT{}
+	other := NewMock(rec)
+	other.On("SendWelcome", MatchesRegex(`.*@example\.com`)).Return(nil)
+	other.Called("SendWelcome", "bob@example.org")
+	other.Called("SendWelcome", 42)
+	if len(rec.errors) != 2 {
+		t.Errorf("Expected non-matching arguments to be rejected, got %v", rec.errors)
+	}
+}
+
+// TestMatchesRegexRejectsInvalidPattern tests that a bad pattern panics at setup.
+func TestMatchesRegexRejectsInvalidPattern(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), `invalid pattern "[a-"`) {
+			t.Errorf("Expected a panic for an invalid pattern, got %v", r)
+		}
+	}()
+	MatchesRegex("[a-")
+}
+
+// TestAndOr tests composing a type matcher with a predicate, and alternatives.
+func TestAndOr(t *testing.T) {
+	named := And(
+		AnythingOfType("*mock.user"),
+		MatchedBy(func(u *user) bool { return u.Name != "" }),
+	)
+	for _, tt := range []struct {
+		arg  any
+		want bool
+	}{
+		{&user{ID: "1", Name: "Alice"}, true},
+		{&user{ID: "2"}, false},
+		{user{ID: "3", Name: "Carol"}, false},
+		{nil, false},
+	} {
+		if got := named.Matches(tt.arg); got != tt.want {
+			t.Errorf("And matching %#v: expected %v, got %v", tt.arg, tt.want, got)
+		}
+	}
+
+	idOrEmpty := Or("123", MatchesRegex("^$"))
+	if !idOrEmpty.Matches("123") || !idOrEmpty.Matches("") || idOrEmpty.Matches("456") {
+		t.Error("Expected Or to match when any alternative matches")
+	}
+
+	rec := &recordingT{}
+	m := NewMock(rec)
+	m.On("Save", named).Return(nil)
+	m.Called("Save", &user{ID: "1", Name: "Alice"})
+	m.Called("Save", &user{ID: "2"})
+	if len(rec.errors) != 1 || !strings.Contains(rec.errors[0], "Unexpected call to Save") {
+		t.Errorf("Expected only the user without a name to be rejected, got %v", rec.errors)
+	}
+}
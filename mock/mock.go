@@ -1,65 +1,204 @@
 package mock
 
 import (
+	"context"
 	"fmt"
 	"reflect"
-	"testing"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/g-restante/GopeherKit.Test/assert"
 )
 
+// TestingT is the subset of *testing.T used by Mock. testing.TB satisfies it,
+// so mocks can also be used in benchmarks.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+	Logf(format string, args ...any)
+}
+
+// Matcher is implemented by expected arguments that decide for themselves
+// whether an actual argument matches.
+type Matcher interface {
+	Matches(actual any) bool
+	String() string
+}
+
 // Any is a placeholder that matches any argument in mock expectations.
 var Any = &anyMatcher{}
 
 type anyMatcher struct{}
 
+func (a *anyMatcher) Matches(actual any) bool {
+	return true
+}
+
 func (a *anyMatcher) String() string {
 	return "mock.Any"
 }
 
-// Mock represents a mock object for testing.
+// AnyVariadic, as the last expected argument, matches any number of remaining
+// arguments, including none. It lets an expectation for a variadic method
+// such as Logf(format string, args ...any) match on the leading arguments
+// only:
+//
+//	m.On("Logf", "hello %s", mock.AnyVariadic)
+//
+// Anywhere else in the argument list it matches a single argument like Any.
+var AnyVariadic = &anyVariadicMatcher{}
+
+type anyVariadicMatcher struct{}
+
+func (a *anyVariadicMatcher) Matches(actual any) bool {
+	return true
+}
+
+func (a *anyVariadicMatcher) String() string {
+	return "mock.AnyVariadic"
+}
+
+// AnyContext matches any argument implementing context.Context.
+var AnyContext = &contextMatcher{}
+
+type contextMatcher struct{}
+
+func (c *contextMatcher) Matches(actual any) bool {
+	_, ok := actual.(context.Context)
+	return ok
+}
+
+func (c *contextMatcher) String() string {
+	return "mock.AnyContext"
+}
+
+// Mock represents a mock object for testing. It is safe for concurrent use
+// once its expectations are configured: calls, assertions and resets are
+// serialized, so code under test may call the mock from many goroutines.
 type Mock struct {
-	t         *testing.T
+	mu        sync.Mutex
+	t         TestingT
+	now       func() time.Time // clock for NotBefore, replaced in tests
+	created   time.Time
 	calls     []*Call
 	callCount map[string]int
+	methods   map[string]reflect.Type
+	history   []Invocation
+	copyArgs  bool
+	lenient   bool
+	recording bool
+	recorded  []Invocation
+}
+
+// Invocation records a single call made on the mock.
+type Invocation struct {
+	Method  string
+	Args    []any
+	Returns []any
 }
 
 // Call represents a mocked method call with its expected arguments and return values.
 type Call struct {
+	mock       *Mock
 	methodName string
 	args       []any
 	returns    []any
 	called     bool
 	callCount  int
+	times      int
+	warned     bool
+	after      []*Call
+	notBefore  time.Duration
 }
 
 // NewMock creates a new mock object.
-func NewMock(t *testing.T) *Mock {
+func NewMock(t TestingT) *Mock {
 	return &Mock{
 		t:         t,
+		now:       time.Now,
+		created:   time.Now(),
 		calls:     make([]*Call, 0),
 		callCount: make(map[string]int),
 	}
 }
 
+// NewMockVerbose is like NewMock, but when the test fails it also logs a
+// summary of the mock's expectations: which were met, which were not, and how
+// often each was called. The summary gives context to failures that are not
+// obviously mock-related. It needs t to provide Cleanup and Failed, as
+// *testing.T does; otherwise it behaves like NewMock.
+func NewMockVerbose(t TestingT) *Mock {
+	m := NewMock(t)
+	if vt, ok := t.(interface {
+		Cleanup(func())
+		Failed() bool
+	}); ok {
+		vt.Cleanup(func() {
+			if vt.Failed() {
+				m.t.Logf("%s", m.summary())
+			}
+		})
+	}
+	return m
+}
+
 // On sets up an expectation for a method call with the given arguments.
 func (m *Mock) On(methodName string, args ...any) *Call {
 	call := &Call{
+		mock:       m,
 		methodName: methodName,
 		args:       args,
 		returns:    make([]any, 0),
 	}
+	m.mu.Lock()
 	m.calls = append(m.calls, call)
+	m.mu.Unlock()
 	return call
 }
 
-// Return sets the return values for the mocked method call.
+// Expectation describes a method stub configured through WithExpectations.
+type Expectation struct {
+	Method  string
+	Args    []any
+	Returns []any
+}
+
+// WithExpectations sets up an On(...).Return(...) pair for each expectation
+// and returns the mock, so a batch of stubs can be configured in one expression.
+func (m *Mock) WithExpectations(expectations ...Expectation) *Mock {
+	m.t.Helper()
+
+	for _, e := range expectations {
+		m.On(e.Method, e.Args...).Return(e.Returns...)
+	}
+	return m
+}
+
+// Return sets the return values for the mocked method call. If the mock is
+// bound to an interface, the values are checked against the method's results.
+// A warning is logged if an earlier expectation without Once or Times has the
+// same arguments, since it would always match instead.
 func (c *Call) Return(values ...any) *Call {
+	c.mock.t.Helper()
+
+	if err := c.mock.checkReturns(c.methodName, values); err != nil {
+		c.mock.t.Errorf("Invalid return values for %s: %v", c.methodName, err)
+	}
+
+	c.mock.mu.Lock()
 	c.returns = values
+	c.mock.warnShadowed(c)
+	c.mock.mu.Unlock()
 	return c
 }
 
 // Times sets the expected number of times this method should be called.
+// Once the call has been matched count times it no longer matches, so later
+// expectations for the same arguments can take over.
 func (c *Call) Times(count int) *Call {
-	// Implementation for call count verification would go here
+	c.times = count
 	return c
 }
 
@@ -68,28 +207,310 @@ func (c *Call) Once() *Call {
 	return c.Times(1)
 }
 
+// After requires every call in prerequisites to be satisfied before this
+// call is matched. Calls without such constraints stay unordered.
+//
+//	find := m.On("FindByID", "1").Return(u, nil)
+//	m.On("Save", mock.Any).Return(nil).After(find)
+func (c *Call) After(prerequisites ...*Call) *Call {
+	c.after = append(c.after, prerequisites...)
+	return c
+}
+
+// Before requires this call to be satisfied before any of next is matched.
+// It is the mirror of After.
+func (c *Call) Before(next ...*Call) *Call {
+	for _, call := range next {
+		call.after = append(call.after, c)
+	}
+	return c
+}
+
+// NotBefore requires the call to happen at least d after the mock was
+// created; an earlier matching call is reported as a failure, though it still
+// returns the configured values. This checks, for example, that debounce
+// logic does not invoke a dependency too soon:
+//
+//	m.On("Flush").Return(nil).NotBefore(100 * time.Millisecond)
+func (c *Call) NotBefore(d time.Duration) *Call {
+	c.notBefore = d
+	return c
+}
+
+// satisfied reports whether the call has been matched, and, if it is limited
+// by Once or Times, matched as often as configured.
+func (c *Call) satisfied() bool {
+	return c.callCount > 0 && (c.times == 0 || c.callCount >= c.times)
+}
+
+// String describes the expectation as its method and arguments.
+func (c *Call) String() string {
+	return fmt.Sprintf("%s%v", c.methodName, c.args)
+}
+
+// checkOrder reports a failure for each prerequisite of call that has not
+// been satisfied yet.
+func (m *Mock) checkOrder(call *Call) {
+	m.t.Helper()
+
+	for _, prerequisite := range call.after {
+		if !prerequisite.satisfied() {
+			m.t.Errorf("Call ordering violated: %s was called before %s was satisfied", call, prerequisite)
+		}
+	}
+}
+
+// checkNotBefore reports a failure if call happens earlier than allowed by
+// NotBefore.
+func (m *Mock) checkNotBefore(call *Call) {
+	m.t.Helper()
+
+	if elapsed := m.now().Sub(m.created); elapsed < call.notBefore {
+		m.t.Errorf("Call to %s happened %v after the mock was created, earlier than the %v set by NotBefore", call, elapsed, call.notBefore)
+	}
+}
+
 // Called marks this call as having been invoked and returns the configured return values.
+// If the mock is bound with BindInterface, a method name that is not in the
+// interface fails the test and returns nil.
 func (m *Mock) Called(methodName string, args ...any) []any {
 	m.t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	
+	if err := m.checkMethod(methodName); err != nil {
+		m.t.Errorf("Call to %s: %v", methodName, err)
+		return nil
+	}
+	
+	recorded := args
+	if m.copyArgs {
+		recorded = deepCopyArgs(args)
+	}
 	
 	// Find matching call
 	for _, call := range m.calls {
 		if call.methodName == methodName && m.argsMatch(call.args, args) {
+			if call.times > 0 && call.callCount >= call.times {
+				continue // call-count qualifier exhausted
+			}
+			m.checkOrder(call)
+			m.checkNotBefore(call)
 			call.called = true
 			call.callCount++
 			m.callCount[methodName]++
+			m.addHistory(Invocation{Method: methodName, Args: recorded, Returns: call.returns}, true)
 			return call.returns
 		}
 	}
 	
 	// No matching call found
+	if m.lenient {
+		returns := m.zeroReturns(methodName)
+		m.addHistory(Invocation{Method: methodName, Args: recorded, Returns: returns}, false)
+		m.t.Logf("Unconfigured call to %s with args: %v; returning zero values", methodName, args)
+		return returns
+	}
+	m.addHistory(Invocation{Method: methodName, Args: recorded}, false)
 	m.t.Errorf("Unexpected call to %s with args: %v", methodName, args)
 	return nil
 }
 
+// warnShadowed logs a warning when an earlier expectation with the same
+// method and arguments as call has no call-count qualifier: it always
+// matches, so call can never be used, which usually means a stale stub in the
+// test setup. The caller must hold m.mu.
+func (m *Mock) warnShadowed(call *Call) {
+	m.t.Helper()
+
+	if call.warned {
+		return
+	}
+
+	for _, earlier := range m.calls {
+		if earlier == call {
+			return
+		}
+		if earlier.times == 0 && earlier.methodName == call.methodName && reflect.DeepEqual(earlier.args, call.args) {
+			call.warned = true
+			m.t.Logf("Warning: ambiguous expectations for %s with args %v: the first one has no Once/Times and always matches, so the later one (returning %v) is never used", call.methodName, call.args, call.returns)
+			return
+		}
+	}
+}
+
+// CalledAuto is like Called but derives the method name from the calling
+// function, so hand-written mock methods don't need to repeat their own name.
+func (m *Mock) CalledAuto(args ...any) []any {
+	m.t.Helper()
+	return m.Called(callerMethod(1), args...)
+}
+
+// CallerMethod returns the name of the method or function that called it,
+// without package path or receiver.
+func CallerMethod() string {
+	return callerMethod(1)
+}
+
+// callerMethod returns the bare name of the function skip frames above its
+// caller. Closure suffixes such as ".func1" are stripped.
+func callerMethod(skip int) string {
+	pcs := make([]uintptr, 1)
+	if runtime.Callers(skip+2, pcs) == 0 {
+		return ""
+	}
+	frame, _ := runtime.CallersFrames(pcs).Next()
+
+	name := frame.Function
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	parts := strings.Split(name, ".")
+	for len(parts) > 1 && isClosureSuffix(parts[len(parts)-1]) {
+		parts = parts[:len(parts)-1]
+	}
+	return parts[len(parts)-1]
+}
+
+// isClosureSuffix reports whether a symbol part names an anonymous function,
+// such as "func1" or a numeric inlining suffix.
+func isClosureSuffix(part string) bool {
+	part = strings.TrimPrefix(part, "func")
+	if part == "" {
+		return false
+	}
+	for _, r := range part {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// CopyArgs enables or disables deep-copying arguments when calls are recorded,
+// so that the call history reflects argument state at call time even if the
+// caller mutates them afterwards. It is disabled by default.
+func (m *Mock) CopyArgs(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.copyArgs = enabled
+}
+
+// Lenient enables or disables lenient mode, in which a call without a
+// matching expectation is logged instead of failing the test. If the mock is
+// bound with BindInterface, such a call returns the zero value of each of the
+// method's results, such as a typed nil *User and a nil error, so that
+// hand-written mock methods can type-assert them; otherwise it returns nil.
+// It is disabled by default.
+func (m *Mock) Lenient(enabled bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lenient = enabled
+}
+
+// zeroReturns returns the zero value of each result of the bound method, or
+// nil if the method is unknown.
+func (m *Mock) zeroReturns(methodName string) []any {
+	methodType, ok := m.methods[methodName]
+	if !ok {
+		return nil
+	}
+	returns := make([]any, methodType.NumOut())
+	for i := range returns {
+		returns[i] = reflect.Zero(methodType.Out(i)).Interface()
+	}
+	return returns
+}
+
+// GetCalls returns the recorded invocations of the given method, in call order.
+func (m *Mock) GetCalls(methodName string) []Invocation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.getCalls(methodName)
+}
+
+func (m *Mock) getCalls(methodName string) []Invocation {
+	var calls []Invocation
+	for _, invocation := range m.history {
+		if invocation.Method == methodName {
+			calls = append(calls, invocation)
+		}
+	}
+	return calls
+}
+
+// Timeline returns every recorded invocation, in the order the calls were
+// made, one per line with its arguments and return values, for example:
+//
+//	1. FindByID(123) -> &{ID:123 Name:Alice}, <nil>
+//	2. Save(&{ID:123 Name:Alice}) -> <nil>
+//
+// Values are rendered with the formatter installed by assert.SetFormatter.
+// Printing it in a failing test shows what the code under test actually did.
+func (m *Mock) Timeline() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+	for i, invocation := range m.history {
+		fmt.Fprintf(&b, "%d. %s(%s)", i+1, invocation.Method, formatValues(invocation.Args))
+		if len(invocation.Returns) > 0 {
+			fmt.Fprintf(&b, " -> %s", formatValues(invocation.Returns))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// formatValues renders values with assert.Format, separated by commas.
+func formatValues(values []any) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = assert.Format(value)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// AssertCalled asserts that the method was called with arguments matching args.
+func (m *Mock) AssertCalled(methodName string, args ...any) {
+	m.t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, invocation := range m.getCalls(methodName) {
+		if m.argsMatch(args, invocation.Args) {
+			return
+		}
+	}
+
+	m.t.Errorf("Expected call to %s with args %v was not made", methodName, args)
+}
+
+// AssertLastCall asserts that the most recent invocation recorded on the mock
+// was methodName with arguments matching args, for example that Close was
+// called after everything else.
+func (m *Mock) AssertLastCall(methodName string, args ...any) {
+	m.t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.history) == 0 {
+		m.t.Errorf("Expected the last call to be %s with args %v, but no calls were made", methodName, args)
+		return
+	}
+
+	last := m.history[len(m.history)-1]
+	if last.Method != methodName || !m.argsMatch(args, last.Args) {
+		m.t.Errorf("Expected the last call to be %s with args %v, but it was %s with args %v", methodName, args, last.Method, last.Args)
+	}
+}
+
 // AssertExpectations verifies that all expected method calls were made.
 func (m *Mock) AssertExpectations() {
 	m.t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	
 	for _, call := range m.calls {
 		if !call.called {
@@ -98,16 +519,70 @@ func (m *Mock) AssertExpectations() {
 	}
 }
 
+// summary describes every expectation, whether it was met and how often it
+// was called, one per line.
+func (m *Mock) summary() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+	b.WriteString("Mock expectations:")
+	for _, call := range m.calls {
+		met := call.called
+		count := fmt.Sprintf("called %d times", call.callCount)
+		if call.callCount == 1 {
+			count = "called once"
+		}
+		if call.times > 0 {
+			met = call.callCount == call.times
+			count = fmt.Sprintf("called %d of %d times", call.callCount, call.times)
+		}
+		status := "met    "
+		if !met {
+			status = "NOT MET"
+		}
+		fmt.Fprintf(&b, "\n  %s %s(%s): %s", status, call.methodName, formatValues(call.args), count)
+	}
+	if len(m.calls) == 0 {
+		b.WriteString(" none")
+	}
+	return b.String()
+}
+
+// AssertExpectationsMet verifies that every expectation limited by Once or
+// Times(n) was consumed exactly n times. Unlike AssertExpectations, which only
+// checks that each expectation was called, it catches leftover Once stubs that
+// could otherwise match calls made later, for example in another subtest.
+func (m *Mock) AssertExpectationsMet() {
+	m.t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, call := range m.calls {
+		if call.times > 0 && call.callCount != call.times {
+			m.t.Errorf("Expectation for %s with args %v was consumed %d of %d times; %d left over", call.methodName, call.args, call.callCount, call.times, call.times-call.callCount)
+		}
+	}
+}
+
 // argsMatch compares two slices of arguments for equality.
+// A trailing AnyVariadic absorbs any remaining actual arguments.
 func (m *Mock) argsMatch(expected, actual []any) bool {
+	if n := len(expected); n > 0 && expected[n-1] == AnyVariadic && len(actual) >= n-1 {
+		expected, actual = expected[:n-1], actual[:n-1]
+	}
+
 	if len(expected) != len(actual) {
 		return false
 	}
 	
 	for i, expectedArg := range expected {
-		// Check if expected argument is mock.Any
-		if _, isAny := expectedArg.(*anyMatcher); isAny {
-			continue // mock.Any matches any value
+		// Matchers such as mock.Any decide for themselves
+		if matcher, ok := expectedArg.(Matcher); ok {
+			if !matcher.Matches(actual[i]) {
+				return false
+			}
+			continue
 		}
 		
 		if !reflect.DeepEqual(expectedArg, actual[i]) {
@@ -118,18 +593,126 @@ func (m *Mock) argsMatch(expected, actual []any) bool {
 	return true
 }
 
+// BindInterface records the method set of the interface pointed to by iface,
+// for example (*UserRepository)(nil), so that expectations can be validated
+// against the real method signatures.
+func (m *Mock) BindInterface(iface any) {
+	m.t.Helper()
+
+	ifaceType := reflect.TypeOf(iface)
+	if ifaceType == nil || ifaceType.Kind() != reflect.Ptr || ifaceType.Elem().Kind() != reflect.Interface {
+		m.t.Errorf("BindInterface expects a pointer to an interface, got %T", iface)
+		return
+	}
+	ifaceType = ifaceType.Elem()
+
+	m.methods = make(map[string]reflect.Type, ifaceType.NumMethod())
+	for i := 0; i < ifaceType.NumMethod(); i++ {
+		method := ifaceType.Method(i)
+		m.methods[method.Name] = method.Type
+	}
+}
+
+// checkMethod reports an error when the mock is bound and methodName is not
+// in the interface's method set, suggesting a method that differs only in
+// case, so that typos such as "FindById" are not mistaken for unexpected calls.
+func (m *Mock) checkMethod(methodName string) error {
+	if m.methods == nil {
+		return nil
+	}
+	if _, ok := m.methods[methodName]; ok {
+		return nil
+	}
+
+	for name := range m.methods {
+		if strings.EqualFold(name, methodName) {
+			return fmt.Errorf("method is not in the bound interface (did you mean %s?)", name)
+		}
+	}
+	return fmt.Errorf("method is not in the bound interface")
+}
+
+// checkReturns validates return values against the bound method signature.
+// It does nothing when the mock is not bound or the method is unknown.
+func (m *Mock) checkReturns(methodName string, values []any) error {
+	methodType, ok := m.methods[methodName]
+	if !ok {
+		return nil
+	}
+
+	if len(values) != methodType.NumOut() {
+		return fmt.Errorf("expected %d return values, got %d", methodType.NumOut(), len(values))
+	}
+
+	for i, value := range values {
+		out := methodType.Out(i)
+		if value == nil {
+			if !isNillable(out.Kind()) {
+				return fmt.Errorf("value %d: nil is not assignable to %s", i, out)
+			}
+			continue
+		}
+
+		if !reflect.TypeOf(value).AssignableTo(out) {
+			return fmt.Errorf("value %d: %T is not assignable to %s", i, value, out)
+		}
+	}
+
+	return nil
+}
+
+// isNillable reports whether values of the given kind can be nil.
+func isNillable(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		return true
+	}
+	return false
+}
+
 // Reset clears all call expectations and history.
 func (m *Mock) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.calls = make([]*Call, 0)
 	m.callCount = make(map[string]int)
+	m.history = nil
+}
+
+// ResetMethod clears the expectations and call count for a single method,
+// leaving the expectations of every other method untouched.
+func (m *Mock) ResetMethod(methodName string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]*Call, 0, len(m.calls))
+	for _, call := range m.calls {
+		if call.methodName != methodName {
+			calls = append(calls, call)
+		}
+	}
+	m.calls = calls
+	delete(m.callCount, methodName)
+
+	history := make([]Invocation, 0, len(m.history))
+	for _, invocation := range m.history {
+		if invocation.Method != methodName {
+			history = append(history, invocation)
+		}
+	}
+	m.history = history
 }
 
 // GetCallCount returns the number of times a method was called.
 func (m *Mock) GetCallCount(methodName string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return m.callCount[methodName]
 }
 
 // String returns a string representation of the mock for debugging.
 func (m *Mock) String() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return fmt.Sprintf("Mock with %d expected calls", len(m.calls))
 }
@@ -0,0 +1,635 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type user struct {
+	ID   string
+	Name string
+}
+
+type userRepository interface {
+	FindByID(id string) (*user, error)
+	Save(u *user) error
+}
+
+// recordingT is a TestingT that records failures and logs instead of reporting them.
+type recordingT struct {
+	errors []string
+	logs   []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingT) Logf(format string, args ...any) {
+	r.logs = append(r.logs, fmt.Sprintf(format, args...))
+}
+
+// TestResetMethod tests that resetting one method keeps the others intact.
+func TestResetMethod(t *testing.T) {
+	m := NewMock(t)
+
+	m.On("FindByID", "123").Return("user", nil)
+	m.On("Save", Any).Return(nil)
+
+	m.Called("FindByID", "123")
+	m.Called("Save", "user")
+
+	m.ResetMethod("FindByID")
+
+	if got := m.GetCallCount("FindByID"); got != 0 {
+		t.Errorf("Expected FindByID call count 0 after reset, got %d", got)
+	}
+
+	if got := m.GetCallCount("Save"); got != 1 {
+		t.Errorf("Expected Save call count 1 to survive reset, got %d", got)
+	}
+
+	if len(m.calls) != 1 || m.calls[0].methodName != "Save" {
+		t.Fatalf("Expected only the Save expectation to remain, got %v", m.calls)
+	}
+
+	m.On("FindByID", "456").Return("other", nil)
+	returns := m.Called("FindByID", "456")
+	if len(returns) != 2 || returns[0] != "other" {
+		t.Errorf("Expected re-stubbed FindByID to return 'other', got %v", returns)
+	}
+
+	m.AssertExpectations()
+}
+
+// TestAnyContext tests that AnyContext matches contexts and rejects other values.
+func TestAnyContext(t *testing.T) {
+	m := NewMock(t)
+
+	m.On("FindByID", AnyContext, "123").Return("user", nil)
+
+	returns := m.Called("FindByID", context.Background(), "123")
+	if len(returns) != 2 || returns[0] != "user" {
+		t.Errorf("Expected call with a context to match, got %v", returns)
+	}
+
+	if m.argsMatch([]any{AnyContext, "123"}, []any{"not a context", "123"}) {
+		t.Error("Expected AnyContext to reject a non-context argument")
+	}
+
+	if m.argsMatch([]any{AnyContext}, []any{nil}) {
+		t.Error("Expected AnyContext to reject a nil argument")
+	}
+}
+
+// TestReturnValidatedAgainstInterface tests that wrong return types are reported at setup.
+func TestReturnValidatedAgainstInterface(t *testing.T) {
+	rec := &recordingT{}
+	m := NewMock(rec)
+	m.BindInterface((*userRepository)(nil))
+
+	m.On("FindByID", "123").Return(&user{ID: "123"}, nil)
+	m.On("Save", Any).Return(nil)
+	if len(rec.errors) != 0 {
+		t.Fatalf("Expected valid returns to pass, got %v", rec.errors)
+	}
+
+	m.On("FindByID", "456").Return("wrong", "types")
+	if len(rec.errors) != 1 {
+		t.Fatalf("Expected one setup failure, got %v", rec.errors)
+	}
+	if !strings.Contains(rec.errors[0], "FindByID") || !strings.Contains(rec.errors[0], "string is not assignable to *mock.user") {
+		t.Errorf("Expected a clear type mismatch message, got %q", rec.errors[0])
+	}
+
+	m.On("Save", Any).Return()
+	if len(rec.errors) != 2 || !strings.Contains(rec.errors[1], "expected 1 return values, got 0") {
+		t.Errorf("Expected a count mismatch failure, got %v", rec.errors)
+	}
+}
+
+// TestReturnUnboundMockSkipsValidation tests that unbound mocks accept any returns.
+func TestReturnUnboundMockSkipsValidation(t *testing.T) {
+	rec := &recordingT{}
+	m := NewMock(rec)
+
+	m.On("FindByID", "123").Return("anything", 42, true)
+
+	if len(rec.errors) != 0 {
+		t.Errorf("Expected no validation without a bound interface, got %v", rec.errors)
+	}
+}
+
+// TestCopyArgsRecordsCallTimeState tests that mutations after a call don't affect recorded arguments.
+func TestCopyArgsRecordsCallTimeState(t *testing.T) {
+	m := NewMock(t)
+	m.CopyArgs(true)
+	m.On("Save", Any).Return(nil)
+
+	u := &user{ID: "1", Name: "John Doe"}
+	m.Called("Save", u)
+	u.Name = "Mutated"
+
+	calls := m.GetCalls("Save")
+	if len(calls) != 1 {
+		t.Fatalf("Expected one recorded Save call, got %d", len(calls))
+	}
+
+	recorded := calls[0].Args[0].(*user)
+	if recorded.Name != "John Doe" {
+		t.Errorf("Expected recorded name 'John Doe', got '%s'", recorded.Name)
+	}
+
+	if recorded == u {
+		t.Error("Expected the recorded argument to be a copy")
+	}
+
+	m.AssertCalled("Save", &user{ID: "1", Name: "John Doe"})
+}
+
+// TestCallHistoryWithoutCopy tests that arguments are recorded by reference by default.
+func TestCallHistoryWithoutCopy(t *testing.T) {
+	m := NewMock(t)
+	m.On("Save", Any).Return(nil)
+
+	u := &user{ID: "1", Name: "John Doe"}
+	m.Called("Save", u)
+	u.Name = "Mutated"
+
+	recorded := m.GetCalls("Save")[0].Args[0].(*user)
+	if recorded != u {
+		t.Error("Expected the recorded argument to be the original pointer")
+	}
+}
+
+// TestAssertCalledFailure tests that a missing call is reported.
+func TestAssertCalledFailure(t *testing.T) {
+	rec := &recordingT{}
+	m := NewMock(rec)
+	m.On("FindByID", Any).Return(nil, nil)
+	m.Called("FindByID", "123")
+
+	m.AssertCalled("FindByID", "456")
+
+	if len(rec.errors) != 1 || !strings.Contains(rec.errors[0], "FindByID") {
+		t.Errorf("Expected a failure for the missing call, got %v", rec.errors)
+	}
+}
+
+// TestDeepCopyPreservesSharedPointers tests cyclic and shared references.
+func TestDeepCopyPreservesSharedPointers(t *testing.T) {
+	type node struct {
+		Next *node
+		Tags []string
+	}
+
+	n := &node{Tags: []string{"a"}}
+	n.Next = n
+
+	copied := deepCopyArgs([]any{n})[0].(*node)
+	if copied == n || copied.Next != copied {
+		t.Error("Expected the cycle to be preserved in the copy")
+	}
+
+	n.Tags[0] = "b"
+	if copied.Tags[0] != "a" {
+		t.Errorf("Expected copied slice to be independent, got %v", copied.Tags)
+	}
+}
+
+// TestDeepCopyPointerToFirstField tests that a pointer to a struct and a
+// pointer to its first field, which share an address, are copied separately.
+func TestDeepCopyPointerToFirstField(t *testing.T) {
+	type account struct {
+		Balance int
+		Owner   string
+	}
+
+	a := &account{Balance: 10, Owner: "alice"}
+	copied := deepCopyArgs([]any{a, &a.Balance})
+	if acc := copied[0].(*account); acc == a || *acc != *a {
+		t.Errorf("Expected an independent copy of the account, got %+v", acc)
+	}
+	if balance := copied[1].(*int); balance == &a.Balance || *balance != 10 {
+		t.Errorf("Expected an independent copy of the balance, got %v", *balance)
+	}
+}
+
+// userRepositoryMock is a hand-written mock that relies on CalledAuto.
+type userRepositoryMock struct {
+	mock *Mock
+}
+
+func (r *userRepositoryMock) FindByID(id string) (*user, error) {
+	results := r.mock.CalledAuto(id)
+	u, _ := results[0].(*user)
+	err, _ := results[1].(error)
+	return u, err
+}
+
+func (r *userRepositoryMock) Save(u *user) error {
+	err, _ := r.mock.CalledAuto(u)[0].(error)
+	return err
+}
+
+// TestCallerMethod tests that the enclosing method name is derived.
+func TestCallerMethod(t *testing.T) {
+	if got := CallerMethod(); got != "TestCallerMethod" {
+		t.Errorf("Expected 'TestCallerMethod', got '%s'", got)
+	}
+
+	func() {
+		if got := CallerMethod(); got != "TestCallerMethod" {
+			t.Errorf("Expected closure to resolve to 'TestCallerMethod', got '%s'", got)
+		}
+	}()
+}
+
+// TestCalledAuto tests that mock methods are matched without hardcoded names.
+func TestCalledAuto(t *testing.T) {
+	m := NewMock(t)
+	repo := &userRepositoryMock{mock: m}
+
+	m.On("FindByID", "123").Return(&user{ID: "123"}, nil)
+	m.On("Save", Any).Return(nil)
+
+	u, err := repo.FindByID("123")
+	if err != nil || u == nil || u.ID != "123" {
+		t.Errorf("Expected user 123, got %v, %v", u, err)
+	}
+
+	if err := repo.Save(u); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	m.AssertExpectations()
+}
+
+// TestWithExpectations tests configuring several stubs in one expression.
+func TestWithExpectations(t *testing.T) {
+	found := &user{ID: "123"}
+
+	m := NewMock(t).WithExpectations(
+		Expectation{Method: "FindByID", Args: []any{"123"}, Returns: []any{found, nil}},
+		Expectation{Method: "Save", Args: []any{Any}, Returns: []any{nil}},
+		Expectation{Method: "Delete", Args: []any{"123"}, Returns: []any{nil}},
+	)
+
+	if returns := m.Called("FindByID", "123"); returns[0] != found {
+		t.Errorf("Expected FindByID to return the configured user, got %v", returns)
+	}
+
+	if returns := m.Called("Save", found); len(returns) != 1 || returns[0] != nil {
+		t.Errorf("Expected Save to return nil, got %v", returns)
+	}
+
+	if returns := m.Called("Delete", "123"); len(returns) != 1 || returns[0] != nil {
+		t.Errorf("Expected Delete to return nil, got %v", returns)
+	}
+
+	m.AssertExpectations()
+}
+
+// TestAmbiguousDuplicateExpectationsWarn tests that shadowed duplicate setups are reported.
+func TestAmbiguousDuplicateExpectationsWarn(t *testing.T) {
+	rec := &recordingT{}
+	m := NewMock(rec)
+
+	m.On("FindByID", "123").Return(&user{Name: "stale"}, nil)
+	m.On("FindByID", "123").Return(&user{Name: "fresh"}, nil)
+
+	if len(rec.logs) != 1 {
+		t.Fatalf("Expected a warning at setup, without any call, got %v", rec.logs)
+	}
+
+	m.Called("FindByID", "123")
+	m.Called("FindByID", "123")
+	if len(rec.logs) != 1 {
+		t.Fatalf("Expected exactly one warning, got %v", rec.logs)
+	}
+
+	if !strings.Contains(rec.logs[0], "ambiguous expectations for FindByID") {
+		t.Errorf("Expected warning to name the method, got %q", rec.logs[0])
+	}
+}
+
+// TestQualifiedDuplicateExpectations tests that Once lets later expectations take over without a warning.
+func TestQualifiedDuplicateExpectations(t *testing.T) {
+	rec := &recordingT{}
+	m := NewMock(rec)
+
+	m.On("FindByID", "123").Return(&user{Name: "first"}, nil).Once()
+	m.On("FindByID", "123").Return(&user{Name: "second"}, nil)
+
+	first := m.Called("FindByID", "123")[0].(*user)
+	second := m.Called("FindByID", "123")[0].(*user)
+
+	if first.Name != "first" || second.Name != "second" {
+		t.Errorf("Expected 'first' then 'second', got '%s' then '%s'", first.Name, second.Name)
+	}
+
+	if len(rec.logs) != 0 || len(rec.errors) != 0 {
+		t.Errorf("Expected no warnings or failures, got %v and %v", rec.logs, rec.errors)
+	}
+}
+
+// TestAssertExpectationsMetReportsLeftoverOnce tests that an unconsumed Once
+// stub from one subtest is reported.
+func TestAssertExpectationsMetReportsLeftoverOnce(t *testing.T) {
+	rec := &recordingT{}
+	m := NewMock(rec)
+
+	t.Run("create", func(t *testing.T) {
+		m.On("Save", Any).Return(nil).Once()
+		m.On("Save", Any).Return(errors.New("duplicate")).Once()
+		m.Called("Save", &user{ID: "1"})
+	})
+
+	t.Run("update", func(t *testing.T) {
+		m.On("FindByID", "1").Return(&user{ID: "1"}, nil).Times(2)
+		m.Called("FindByID", "1")
+		m.Called("FindByID", "1")
+	})
+
+	m.AssertExpectations()
+	if len(rec.errors) != 1 {
+		t.Fatalf("Expected AssertExpectations to report only the uncalled stub, got %v", rec.errors)
+	}
+
+	rec.errors = nil
+	m.AssertExpectationsMet()
+	if len(rec.errors) != 1 {
+		t.Fatalf("Expected 1 leftover expectation, got %v", rec.errors)
+	}
+	if !strings.Contains(rec.errors[0], "Save") || !strings.Contains(rec.errors[0], "consumed 0 of 1 times") {
+		t.Errorf("Unexpected failure message: %s", rec.errors[0])
+	}
+}
+
+// TestAfterEnforcesPartialOrder tests one After constraint while other calls
+// stay unordered.
+func TestAfterEnforcesPartialOrder(t *testing.T) {
+	newMock := func(rec *recordingT) *Mock {
+		m := NewMock(rec)
+		find := m.On("FindByID", "1").Return(&user{ID: "1"}, nil)
+		m.On("Save", Any).Return(nil).After(find)
+		m.On("Count").Return(2)
+		return m
+	}
+
+	rec := &recordingT{}
+	m := newMock(rec)
+	m.Called("Count")
+	m.Called("FindByID", "1")
+	m.Called("Count")
+	m.Called("Save", &user{ID: "1"})
+	if len(rec.errors) != 0 {
+		t.Errorf("Expected the ordered workflow to pass, got %v", rec.errors)
+	}
+
+	rec = &recordingT{}
+	m = newMock(rec)
+	m.Called("Save", &user{ID: "1"})
+	m.Called("FindByID", "1")
+	if len(rec.errors) != 1 {
+		t.Fatalf("Expected 1 ordering violation, got %v", rec.errors)
+	}
+	if !strings.Contains(rec.errors[0], "Save[mock.Any] was called before FindByID[1] was satisfied") {
+		t.Errorf("Expected the violation to name both calls, got %s", rec.errors[0])
+	}
+}
+
+// TestBeforeMirrorsAfter tests that Before adds the same constraint as After.
+func TestBeforeMirrorsAfter(t *testing.T) {
+	rec := &recordingT{}
+	m := NewMock(rec)
+	save := m.On("Save", Any).Return(nil)
+	m.On("FindByID", "1").Return(nil, nil).Once().Before(save)
+
+	m.Called("Save", &user{ID: "1"})
+	if len(rec.errors) != 1 || !strings.Contains(rec.errors[0], "before FindByID[1] was satisfied") {
+		t.Errorf("Expected an ordering violation, got %v", rec.errors)
+	}
+}
+
+// TestAnyVariadicAbsorbsTail tests that a trailing AnyVariadic matches zero,
+// one and two variadic arguments.
+func TestAnyVariadicAbsorbsTail(t *testing.T) {
+	rec := &recordingT{}
+	m := NewMock(rec)
+	m.On("Logf", "hello %s", AnyVariadic)
+
+	m.Called("Logf", "hello %s")
+	m.Called("Logf", "hello %s", "world")
+	m.Called("Logf", "hello %s", "world", 42)
+	if len(rec.errors) != 0 {
+		t.Fatalf("Expected every call to match, got %v", rec.errors)
+	}
+	if got := m.GetCallCount("Logf"); got != 3 {
+		t.Errorf("Expected 3 calls, got %d", got)
+	}
+
+	m.Called("Logf", "goodbye %s", "world")
+	if len(rec.errors) != 1 {
+		t.Errorf("Expected a different format not to match, got %v", rec.errors)
+	}
+
+	if m.argsMatch([]any{"a", AnyVariadic}, []any{}) {
+		t.Error("Expected AnyVariadic not to absorb missing leading arguments")
+	}
+}
+
+// Mocks accept *testing.B as well as *testing.T.
+var _ TestingT = testing.TB(nil)
+
+// BenchmarkCalled measures matching a call against a few expectations.
+func BenchmarkCalled(b *testing.B) {
+	m := NewMock(b)
+	m.On("FindByID", "1").Return(&user{ID: "1"}, nil)
+	m.On("FindByID", "2").Return(&user{ID: "2"}, nil)
+	m.On("Save", Any).Return(nil)
+
+	for i := 0; i < b.N; i++ {
+		m.Called("FindByID", "2")
+	}
+}
+
+// TestTimeline tests that the timeline lists invocations in call order with
+// their arguments and return values.
+func TestTimeline(t *testing.T) {
+	alice := &user{ID: "123", Name: "Alice"}
+
+	m := NewMock(t)
+	m.On("FindByID", "123").Return(alice, nil)
+	m.On("Save", alice).Return(nil)
+	m.On("Count").Return(2)
+
+	m.Called("FindByID", "123")
+	m.Called("Save", alice)
+	m.Called("Count")
+	m.Called("FindByID", "123")
+
+	want := "1. FindByID(123) -> &{ID:123 Name:Alice}, <nil>\n" +
+		"2. Save(&{ID:123 Name:Alice}) -> <nil>\n" +
+		"3. Count() -> 2\n" +
+		"4. FindByID(123) -> &{ID:123 Name:Alice}, <nil>\n"
+	if got := m.Timeline(); got != want {
+		t.Errorf("Unexpected timeline:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// failingT is a recordingT that runs cleanups on demand and reports whether
+// any failure was recorded.
+type failingT struct {
+	recordingT
+	cleanups []func()
+}
+
+func (f *failingT) Cleanup(fn func()) { f.cleanups = append(f.cleanups, fn) }
+func (f *failingT) Failed() bool      { return len(f.errors) > 0 }
+
+func (f *failingT) finish() {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+}
+
+// TestNewMockVerbose tests that a failed test logs which expectations were met.
+func TestNewMockVerbose(t *testing.T) {
+	rec := &failingT{}
+	m := NewMockVerbose(rec)
+	m.On("FindByID", "123").Return(nil, nil)
+	m.On("Save", Any).Return(nil).Times(2)
+	m.On("Delete", "9").Return(nil)
+
+	m.Called("FindByID", "123")
+	m.Called("Save", "alice")
+	rec.Errorf("unrelated failure")
+	rec.finish()
+
+	want := "Mock expectations:\n" +
+		"  met     FindByID(123): called once\n" +
+		"  NOT MET Save(mock.Any): called 1 of 2 times\n" +
+		"  NOT MET Delete(9): called 0 times"
+	if len(rec.logs) != 1 || rec.logs[0] != want {
+		t.Errorf("Unexpected summary:\n%v\nwant:\n%s", rec.logs, want)
+	}
+
+	passing := &failingT{}
+	NewMockVerbose(passing).On("FindByID", "123")
+	passing.finish()
+	if len(passing.logs) != 0 {
+		t.Errorf("Expected no summary for a passing test, got %v", passing.logs)
+	}
+}
+
+// TestLenientReturnsTypedZeros tests that an unconfigured call in lenient mode
+// returns the zero values of the bound method's results instead of failing.
+func TestLenientReturnsTypedZeros(t *testing.T) {
+	rec := &recordingT{}
+	m := NewMock(rec)
+	m.BindInterface((*userRepository)(nil))
+	m.Lenient(true)
+
+	repo := &userRepositoryMock{mock: m}
+	if u, err := repo.FindByID("123"); u != nil || err != nil {
+		t.Errorf("Expected zero values, got %v, %v", u, err)
+	}
+
+	results := m.Called("FindByID", "456")
+	if len(results) != 2 {
+		t.Fatalf("Expected one value per result, got %v", results)
+	}
+	if u := results[0].(*user); u != nil {
+		t.Errorf("Expected a typed nil *user, got %v", u)
+	}
+	if results[1] != nil {
+		t.Errorf("Expected a nil error, got %v", results[1])
+	}
+
+	if len(rec.errors) != 0 || len(rec.logs) != 2 || !strings.Contains(rec.logs[0], "Unconfigured call to FindByID") {
+		t.Errorf("Expected unconfigured calls to be logged, not failed, got errors %v and logs %v", rec.errors, rec.logs)
+	}
+
+	m.Lenient(false)
+	m.Called("FindByID", "789")
+	if len(rec.errors) != 1 {
+		t.Errorf("Expected strict mode to fail unexpected calls, got %v", rec.errors)
+	}
+}
+
+// TestNotBefore tests that a call made too soon is flagged while a delayed
+// one passes.
+func TestNotBefore(t *testing.T) {
+	rec := &recordingT{}
+	m := NewMock(rec)
+	clock := m.created
+	m.now = func() time.Time { return clock }
+	m.On("Flush").Return(nil).NotBefore(20 * time.Millisecond)
+
+	clock = clock.Add(19 * time.Millisecond)
+	m.Called("Flush")
+	if len(rec.errors) != 1 || !strings.Contains(rec.errors[0], "happened 19ms after the mock was created, earlier than the 20ms set by NotBefore") {
+		t.Fatalf("Expected the early call to be flagged, got %v", rec.errors)
+	}
+
+	clock = clock.Add(time.Millisecond)
+	m.Called("Flush")
+	if len(rec.errors) != 1 {
+		t.Errorf("Expected the delayed call to pass, got %v", rec.errors)
+	}
+}
+
+// TestAssertLastCall tests that the final invocation is checked.
+func TestAssertLastCall(t *testing.T) {
+	rec := &recordingT{}
+	m := NewMock(rec)
+	m.On("Save", Any).Return(nil)
+	m.On("Close").Return(nil)
+
+	m.AssertLastCall("Close")
+	m.Called("Save", "alice")
+	m.Called("Close")
+	m.AssertLastCall("Close")
+	if len(rec.errors) != 1 || !strings.Contains(rec.errors[0], "no calls were made") {
+		t.Fatalf("Expected only the check before any call to fail, got %v", rec.errors)
+	}
+
+	m.Called("Save", "bob")
+	m.AssertLastCall("Close")
+	if len(rec.errors) != 2 || !strings.Contains(rec.errors[1], "but it was Save with args [bob]") {
+		t.Errorf("Expected a call after Close to fail, got %v", rec.errors)
+	}
+}
+
+// TestCalledValidatesMethodName tests that a method name missing from the
+// bound interface is flagged instead of reported as an unexpected call.
+func TestCalledValidatesMethodName(t *testing.T) {
+	rec := &recordingT{}
+	m := NewMock(rec)
+	m.BindInterface((*userRepository)(nil))
+	m.On("FindByID", "123").Return(&user{ID: "123"}, nil)
+
+	if results := m.Called("FindById", "123"); results != nil {
+		t.Errorf("Expected no return values, got %v", results)
+	}
+	if len(rec.errors) != 1 || !strings.Contains(rec.errors[0], "not in the bound interface (did you mean FindByID?)") {
+		t.Fatalf("Expected the misspelled method to be flagged, got %v", rec.errors)
+	}
+
+	m.Called("Delete", "123")
+	if len(rec.errors) != 2 || !strings.Contains(rec.errors[1], "Call to Delete: method is not in the bound interface") {
+		t.Errorf("Expected the unknown method to be flagged, got %v", rec.errors)
+	}
+
+	m.Called("FindByID", "123")
+	if len(rec.errors) != 2 {
+		t.Errorf("Expected the bound method to pass, got %v", rec.errors)
+	}
+}
@@ -0,0 +1,169 @@
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// recordedCall is the serialized form of an Invocation.
+type recordedCall struct {
+	Method  string          `json:"method"`
+	Args    []recordedValue `json:"args"`
+	Returns []recordedValue `json:"returns"`
+}
+
+// recordedValue holds a JSON-encoded value, or the message of an error,
+// which JSON cannot represent on its own.
+type recordedValue struct {
+	Value json.RawMessage `json:"value,omitempty"`
+	Error *string         `json:"error,omitempty"`
+}
+
+// Record starts capturing every successful invocation, with its arguments and
+// return values, for ExportJSON. Combined with Spy it snapshots the behavior
+// of a real dependency so it can be replayed later with LoadFromJSON.
+func (m *Mock) Record() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.recording = true
+	m.recorded = nil
+}
+
+// addHistory appends an invocation to the call history and, while recording,
+// to the recorded interactions if it succeeded, that is matched an expectation
+// or was forwarded by a spy. The caller must hold m.mu.
+func (m *Mock) addHistory(invocation Invocation, matched bool) {
+	m.history = append(m.history, invocation)
+	if matched && m.recording {
+		m.recorded = append(m.recorded, invocation)
+	}
+}
+
+// ExportJSON serializes the invocations captured since Record. Arguments and
+// return values are encoded with encoding/json; errors are kept as their
+// message. It reports a failure and returns nil if a value cannot be encoded,
+// for example a channel or a func.
+func (m *Mock) ExportJSON() []byte {
+	m.t.Helper()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	calls := make([]recordedCall, len(m.recorded))
+	for i, invocation := range m.recorded {
+		args, err := encodeValues(invocation.Args)
+		if err == nil {
+			calls[i].Returns, err = encodeValues(invocation.Returns)
+		}
+		if err != nil {
+			m.t.Errorf("Cannot export call to %s with args %v: %v", invocation.Method, invocation.Args, err)
+			return nil
+		}
+		calls[i].Method = invocation.Method
+		calls[i].Args = args
+	}
+
+	data, err := json.MarshalIndent(calls, "", "  ")
+	if err != nil {
+		m.t.Errorf("Cannot export recorded calls: %v", err)
+		return nil
+	}
+	return data
+}
+
+func encodeValues(values []any) ([]recordedValue, error) {
+	encoded := make([]recordedValue, len(values))
+	for i, value := range values {
+		if err, ok := value.(error); ok && err != nil {
+			message := err.Error()
+			encoded[i].Error = &message
+			continue
+		}
+
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("value %d: %w", i, err)
+		}
+		encoded[i].Value = raw
+	}
+	return encoded, nil
+}
+
+// LoadFromJSON builds a mock whose expectations replay the interactions
+// exported by ExportJSON. Each recorded call becomes a Once expectation, so
+// repeated calls with the same arguments return their recorded results in
+// order. Arguments match when they encode to the same JSON. Return values
+// are decoded lazily into the result types by ResultAs, which generated mocks
+// use; recorded errors come back as errors with the same message.
+func LoadFromJSON(t TestingT, data []byte) *Mock {
+	t.Helper()
+
+	m := NewMock(t)
+
+	var calls []recordedCall
+	if err := json.Unmarshal(data, &calls); err != nil {
+		t.Errorf("Cannot load recorded calls: %v", err)
+		return m
+	}
+
+	for _, call := range calls {
+		args := make([]any, len(call.Args))
+		for i, arg := range call.Args {
+			args[i] = &jsonMatcher{recorded: arg}
+		}
+		returns := make([]any, len(call.Returns))
+		for i, ret := range call.Returns {
+			returns[i] = ret.decode()
+		}
+		m.On(call.Method, args...).Return(returns...).Once()
+	}
+
+	return m
+}
+
+// decode returns the recorded error, nil for a JSON null, or the raw JSON for
+// returnValue to decode into the requested type.
+func (v recordedValue) decode() any {
+	if v.Error != nil {
+		return errors.New(*v.Error)
+	}
+	if len(v.Value) == 0 || bytes.Equal(v.Value, []byte("null")) {
+		return nil
+	}
+	return v.Value
+}
+
+// jsonMatcher matches an argument that encodes to the same JSON as a
+// recorded one.
+type jsonMatcher struct {
+	recorded recordedValue
+}
+
+func (j *jsonMatcher) Matches(actual any) bool {
+	if err, ok := actual.(error); ok && err != nil {
+		return j.recorded.Error != nil && *j.recorded.Error == err.Error()
+	}
+	if j.recorded.Error != nil {
+		return false
+	}
+
+	raw, err := json.Marshal(actual)
+	if err != nil {
+		return false
+	}
+
+	var want, got any
+	if json.Unmarshal(j.recorded.Value, &want) != nil || json.Unmarshal(raw, &got) != nil {
+		return false
+	}
+	return reflect.DeepEqual(want, got)
+}
+
+func (j *jsonMatcher) String() string {
+	if j.recorded.Error != nil {
+		return fmt.Sprintf("error(%q)", *j.recorded.Error)
+	}
+	return string(j.recorded.Value)
+}
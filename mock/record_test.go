@@ -0,0 +1,76 @@
+package mock
+
+import (
+	"strings"
+	"testing"
+)
+
+// replayedUserRepository adapts a replayed Mock to the userRepository interface.
+type replayedUserRepository struct {
+	*Mock
+}
+
+func (r replayedUserRepository) FindByID(id string) (*user, error) {
+	results := r.Called("FindByID", id)
+	return ResultAs[*user](results, 0), ResultAs[error](results, 1)
+}
+
+func (r replayedUserRepository) Save(u *user) error {
+	return ResultAs[error](r.Called("Save", u), 0)
+}
+
+// TestRecordExportLoadReplay tests that replayed calls return what the real
+// implementation returned.
+func TestRecordExportLoadReplay(t *testing.T) {
+	real := &memoryUserRepository{users: map[string]*user{}}
+	spy := userRepositorySpy{Spy[userRepository](t, real)}
+	spy.Record()
+
+	var repo userRepository = spy
+	saveErr := repo.Save(&user{ID: "1", Name: "Alice"})
+	found, foundErr := repo.FindByID("1")
+	missing, missingErr := repo.FindByID("2")
+
+	data := spy.ExportJSON()
+	if !strings.Contains(string(data), `"error": "user not found"`) {
+		t.Errorf("Expected the error to be exported by message, got:\n%s", data)
+	}
+
+	rec := &recordingT{}
+	replayed := replayedUserRepository{LoadFromJSON(rec, data)}
+	repo = replayed
+
+	if err := repo.Save(&user{ID: "1", Name: "Alice"}); err != saveErr {
+		t.Errorf("Expected Save to return %v, got %v", saveErr, err)
+	}
+	u, err := repo.FindByID("1")
+	if *u != *found || err != foundErr {
+		t.Errorf("Expected FindByID(1) to return %v, %v, got %v, %v", found, foundErr, u, err)
+	}
+	u, err = repo.FindByID("2")
+	if u != missing || err == nil || err.Error() != missingErr.Error() {
+		t.Errorf("Expected FindByID(2) to return %v, %v, got %v, %v", missing, missingErr, u, err)
+	}
+	if len(rec.errors) != 0 {
+		t.Errorf("Expected the replay to match every call, got %v", rec.errors)
+	}
+	replayed.AssertExpectationsMet()
+
+	repo.FindByID("3")
+	if len(rec.errors) != 1 || !strings.Contains(rec.errors[0], "Unexpected call to FindByID") {
+		t.Errorf("Expected an unrecorded call to fail, got %v", rec.errors)
+	}
+}
+
+// TestExportJSONUnsupportedValue tests that values JSON cannot encode are reported.
+func TestExportJSONUnsupportedValue(t *testing.T) {
+	rec := &recordingT{}
+	m := NewMock(rec)
+	m.Record()
+	m.On("Subscribe", Any).Return(nil)
+	m.Called("Subscribe", make(chan int))
+
+	if data := m.ExportJSON(); data != nil || len(rec.errors) != 1 {
+		t.Errorf("Expected the export to fail, got %s and %v", data, rec.errors)
+	}
+}
@@ -0,0 +1,97 @@
+package mock
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// SpyMock forwards calls to a real implementation of the interface T and
+// records every invocation in the embedded Mock's history, so GetCalls,
+// GetCallCount and AssertCalled work against a live object.
+//
+// Go cannot synthesize interface methods at run time, so the spy is used
+// from a thin wrapper type whose methods call Forward or ForwardAuto:
+//
+//	type repoSpy struct{ *mock.SpyMock[UserRepository] }
+//
+//	func (s repoSpy) FindByID(id string) (*User, error) {
+//		r := s.ForwardAuto(id)
+//		u, _ := r[0].(*User)
+//		err, _ := r[1].(error)
+//		return u, err
+//	}
+type SpyMock[T any] struct {
+	*Mock
+	real reflect.Value
+}
+
+// Spy creates a SpyMock that forwards to real. It panics if T is not an
+// interface type.
+func Spy[T any](t TestingT, real T) *SpyMock[T] {
+	ifaceType := reflect.TypeOf((*T)(nil)).Elem()
+	if ifaceType.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("mock.Spy: %s is not an interface type", ifaceType))
+	}
+
+	s := &SpyMock[T]{Mock: NewMock(t), real: reflect.ValueOf(&real).Elem()}
+	s.BindInterface((*T)(nil))
+	return s
+}
+
+// Forward calls methodName on the real implementation with args, records the
+// invocation and returns the real results.
+func (s *SpyMock[T]) Forward(methodName string, args ...any) []any {
+	s.t.Helper()
+
+	s.mu.Lock()
+	copyArgs := s.copyArgs
+	s.mu.Unlock()
+
+	recorded := args
+	if copyArgs {
+		recorded = deepCopyArgs(args)
+	}
+
+	method := s.real.MethodByName(methodName)
+	if !method.IsValid() || method.Type().NumIn() != len(args) {
+		s.mu.Lock()
+		s.addHistory(Invocation{Method: methodName, Args: recorded}, false)
+		s.mu.Unlock()
+		s.t.Errorf("Spy cannot forward %s with args %v: no such method with %d parameters", methodName, args, len(args))
+		return nil
+	}
+
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		if arg == nil {
+			in[i] = reflect.Zero(method.Type().In(i))
+			continue
+		}
+		in[i] = reflect.ValueOf(arg)
+	}
+
+	var out []reflect.Value
+	if method.Type().IsVariadic() {
+		out = method.CallSlice(in)
+	} else {
+		out = method.Call(in)
+	}
+
+	results := make([]any, len(out))
+	for i, v := range out {
+		results[i] = v.Interface()
+	}
+
+	s.mu.Lock()
+	s.callCount[methodName]++
+	s.addHistory(Invocation{Method: methodName, Args: recorded, Returns: results}, true)
+	s.mu.Unlock()
+	return results
+}
+
+// ForwardAuto is like Forward but derives the method name from the calling
+// function, like Mock.CalledAuto.
+func (s *SpyMock[T]) ForwardAuto(args ...any) []any {
+	s.t.Helper()
+	return s.Forward(callerMethod(1), args...)
+}
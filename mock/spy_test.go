@@ -0,0 +1,123 @@
+package mock
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+// memoryUserRepository is a real, in-memory userRepository.
+type memoryUserRepository struct {
+	users map[string]*user
+}
+
+func (r *memoryUserRepository) FindByID(id string) (*user, error) {
+	u, ok := r.users[id]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	return u, nil
+}
+
+func (r *memoryUserRepository) Save(u *user) error {
+	r.users[u.ID] = u
+	return nil
+}
+
+// userRepositorySpy adapts a SpyMock to the userRepository interface.
+type userRepositorySpy struct {
+	*SpyMock[userRepository]
+}
+
+func (s userRepositorySpy) FindByID(id string) (*user, error) {
+	results := s.ForwardAuto(id)
+	u, _ := results[0].(*user)
+	err, _ := results[1].(error)
+	return u, err
+}
+
+func (s userRepositorySpy) Save(u *user) error {
+	err, _ := s.ForwardAuto(u)[0].(error)
+	return err
+}
+
+// TestSpyForwardsAndRecords tests that a spy returns real results and records calls.
+func TestSpyForwardsAndRecords(t *testing.T) {
+	real := &memoryUserRepository{users: map[string]*user{}}
+	var repo userRepository = userRepositorySpy{Spy[userRepository](t, real)}
+	spy := repo.(userRepositorySpy)
+
+	alice := &user{ID: "1", Name: "Alice"}
+	if err := repo.Save(alice); err != nil {
+		t.Fatalf("Expected Save to succeed, got %v", err)
+	}
+
+	got, err := repo.FindByID("1")
+	if err != nil || got != alice {
+		t.Errorf("Expected the real repository's user, got %v, %v", got, err)
+	}
+
+	if _, err := repo.FindByID("2"); err == nil || err.Error() != "user not found" {
+		t.Errorf("Expected the real repository's error, got %v", err)
+	}
+
+	spy.AssertCalled("Save", alice)
+	spy.AssertCalled("FindByID", "1")
+
+	if count := spy.GetCallCount("FindByID"); count != 2 {
+		t.Errorf("Expected 2 FindByID calls, got %d", count)
+	}
+
+	calls := spy.GetCalls("FindByID")
+	if len(calls) != 2 || calls[0].Returns[0] != alice {
+		t.Errorf("Expected recorded returns to hold the real result, got %v", calls)
+	}
+}
+
+// TestSpyUnknownMethod tests that forwarding to a missing method fails the test.
+func TestSpyUnknownMethod(t *testing.T) {
+	rec := &recordingT{}
+	spy := Spy[userRepository](rec, &memoryUserRepository{})
+
+	if results := spy.Forward("Delete", "1"); results != nil {
+		t.Errorf("Expected nil results, got %v", results)
+	}
+
+	if len(rec.errors) != 1 {
+		t.Fatalf("Expected 1 error, got %v", rec.errors)
+	}
+}
+
+// TestSpyRequiresInterface tests that Spy panics for non-interface types.
+func TestSpyRequiresInterface(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected Spy to panic for a non-interface type")
+		}
+	}()
+
+	Spy[*memoryUserRepository](t, &memoryUserRepository{})
+}
+
+// TestSpyConcurrentForward tests that a spy can be called from several
+// goroutines while its options change; run with -race.
+func TestSpyConcurrentForward(t *testing.T) {
+	real := &memoryUserRepository{users: map[string]*user{"1": {ID: "1", Name: "Alice"}}}
+	repo := userRepositorySpy{Spy[userRepository](t, real)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			repo.CopyArgs(i%2 == 0)
+			repo.FindByID("1")
+		}()
+	}
+	wg.Wait()
+
+	if got := repo.GetCallCount("FindByID"); got != 8 {
+		t.Errorf("Expected 8 recorded calls, got %d", got)
+	}
+}
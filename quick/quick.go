@@ -0,0 +1,332 @@
+package quick
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+
+	"github.com/g-restante/GopeherKit.Test/gen"
+)
+
+// TestingT is the subset of *testing.T used by Check.
+type TestingT interface {
+	Helper()
+	Name() string
+	Errorf(format string, args ...any)
+}
+
+// Config tunes Check. Zero fields fall back to their defaults.
+type Config struct {
+	// MaxCount is the number of random inputs to try. Defaults to 100.
+	MaxCount int
+	// Seed seeds the random source. Defaults to a seed derived from t.Name().
+	Seed int64
+	// MaxShrinks bounds the number of shrinking steps. Defaults to 1000.
+	MaxShrinks int
+}
+
+const (
+	defaultMaxCount   = 100
+	defaultMaxShrinks = 1000
+)
+
+// Check calls property with random arguments until it fails or MaxCount
+// inputs have passed. property must be a function returning bool or error;
+// a false result, a non-nil error or a panic counts as a failure. Arguments
+// are generated with gen.Value, so the same test always sees the same inputs
+// unless Config.Seed is set.
+//
+// A failing input is shrunk towards zero values, empty strings and shorter
+// collections for as long as the property keeps failing, and the smallest
+// counterexample found is reported.
+func Check(t TestingT, property any, config ...Config) {
+	t.Helper()
+
+	fn := reflect.ValueOf(property)
+	if err := validateProperty(fn); err != nil {
+		t.Errorf("quick.Check: %v", err)
+		return
+	}
+
+	c := Config{}
+	if len(config) > 0 {
+		c = config[0]
+	}
+	if c.MaxCount <= 0 {
+		c.MaxCount = defaultMaxCount
+	}
+	if c.MaxShrinks <= 0 {
+		c.MaxShrinks = defaultMaxShrinks
+	}
+	if c.Seed == 0 {
+		c.Seed = gen.SeedFor(t.Name())
+	}
+
+	r := rand.New(rand.NewSource(c.Seed))
+	for i := 1; i <= c.MaxCount; i++ {
+		args := make([]reflect.Value, fn.Type().NumIn())
+		for j := range args {
+			args[j] = gen.Value(r, fn.Type().In(j))
+		}
+
+		failure := run(fn, args)
+		if failure == "" {
+			continue
+		}
+
+		original := formatArgs(args)
+		shrunk, steps, failure := shrinkArgs(fn, args, failure, c.MaxShrinks)
+		t.Errorf("Property failed after %d tests (seed %d)\nCounterexample (shrunk in %d steps):\n%s\nOriginal input:\n%s\nFailure: %s",
+			i, c.Seed, steps, formatArgs(shrunk), original, failure)
+		return
+	}
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// validateProperty checks that fn is a function with at least one parameter
+// returning a single bool or error.
+func validateProperty(fn reflect.Value) error {
+	if fn.Kind() != reflect.Func || fn.IsNil() {
+		return fmt.Errorf("property must be a function, got %s", describeType(fn))
+	}
+	typ := fn.Type()
+	if typ.NumIn() == 0 {
+		return fmt.Errorf("property %s takes no arguments", typ)
+	}
+	if typ.IsVariadic() {
+		return fmt.Errorf("property %s must not be variadic", typ)
+	}
+	if typ.NumOut() != 1 || (typ.Out(0).Kind() != reflect.Bool && typ.Out(0) != errorType) {
+		return fmt.Errorf("property %s must return a bool or an error", typ)
+	}
+	return nil
+}
+
+func describeType(v reflect.Value) string {
+	if !v.IsValid() {
+		return "nil"
+	}
+	return v.Type().String()
+}
+
+// run calls fn with args and describes the failure, or returns "" if the
+// property held.
+func run(fn reflect.Value, args []reflect.Value) (failure string) {
+	defer func() {
+		if r := recover(); r != nil {
+			failure = fmt.Sprintf("panic: %v", r)
+		}
+	}()
+
+	out := fn.Call(args)[0]
+	if out.Kind() == reflect.Bool {
+		if out.Bool() {
+			return ""
+		}
+		return "property returned false"
+	}
+	if out.IsNil() {
+		return ""
+	}
+	return out.Interface().(error).Error()
+}
+
+// shrinkArgs greedily replaces arguments with smaller candidates that still
+// fail the property, until no candidate fails or maxSteps is reached.
+func shrinkArgs(fn reflect.Value, args []reflect.Value, failure string, maxSteps int) ([]reflect.Value, int, string) {
+	steps := 0
+	for steps < maxSteps {
+		improved := false
+		for i := range args {
+			for _, candidate := range shrink(args[i]) {
+				trial := append([]reflect.Value(nil), args...)
+				trial[i] = candidate
+				if f := run(fn, trial); f != "" {
+					args, failure = trial, f
+					improved = true
+					steps++
+					break
+				}
+			}
+			if improved {
+				break
+			}
+		}
+		if !improved {
+			break
+		}
+	}
+	return args, steps, failure
+}
+
+// shrink returns candidates smaller than v, most aggressive first.
+func shrink(v reflect.Value) []reflect.Value {
+	typ := v.Type()
+	var candidates []reflect.Value
+	add := func(c reflect.Value) {
+		candidates = append(candidates, c)
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			add(reflect.Zero(typ))
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := v.Int()
+		if n == 0 {
+			break
+		}
+		for _, c := range []int64{0, n / 2, n - sign(n)} {
+			if c != n {
+				x := reflect.New(typ).Elem()
+				x.SetInt(c)
+				add(x)
+			}
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n := v.Uint()
+		if n == 0 {
+			break
+		}
+		for _, c := range []uint64{0, n / 2, n - 1} {
+			if c != n {
+				x := reflect.New(typ).Elem()
+				x.SetUint(c)
+				add(x)
+			}
+		}
+
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		for _, c := range []float64{0, float64(int64(f)), f / 2} {
+			if c != f {
+				x := reflect.New(typ).Elem()
+				x.SetFloat(c)
+				add(x)
+			}
+		}
+
+	case reflect.String:
+		s := v.String()
+		if s == "" {
+			break
+		}
+		add(reflect.Zero(typ))
+		// Shrink by rune so that multibyte input stays valid UTF-8.
+		runes := []rune(s)
+		variants := []string{string(runes[:len(runes)/2]), string(runes[len(runes)/2:])}
+		for i := range runes {
+			variants = append(variants, string(runes[:i])+string(runes[i+1:]))
+		}
+		for _, c := range variants {
+			if c != s {
+				x := reflect.New(typ).Elem()
+				x.SetString(c)
+				add(x)
+			}
+		}
+
+	case reflect.Slice:
+		n := v.Len()
+		if v.IsNil() {
+			break
+		}
+		if n == 0 {
+			add(reflect.Zero(typ))
+			break
+		}
+		add(reflect.MakeSlice(typ, 0, 0))
+		if n > 1 {
+			add(copySlice(v, 0, n/2))
+			add(copySlice(v, n/2, n))
+		}
+		for i := 0; i < n; i++ {
+			add(reflect.AppendSlice(copySlice(v, 0, i), v.Slice(i+1, n)))
+		}
+		for i := 0; i < n; i++ {
+			for _, elem := range shrink(v.Index(i)) {
+				c := copySlice(v, 0, n)
+				c.Index(i).Set(elem)
+				add(c)
+			}
+		}
+
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			for _, elem := range shrink(v.Index(i)) {
+				c := reflect.New(typ).Elem()
+				reflect.Copy(c, v)
+				c.Index(i).Set(elem)
+				add(c)
+			}
+		}
+
+	case reflect.Map:
+		if v.IsNil() || v.Len() == 0 {
+			break
+		}
+		for _, key := range v.MapKeys() {
+			c := reflect.MakeMapWithSize(typ, v.Len()-1)
+			for _, k := range v.MapKeys() {
+				if k.Interface() != key.Interface() {
+					c.SetMapIndex(k, v.MapIndex(k))
+				}
+			}
+			add(c)
+		}
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			break
+		}
+		add(reflect.Zero(typ))
+		for _, elem := range shrink(v.Elem()) {
+			p := reflect.New(typ.Elem())
+			p.Elem().Set(elem)
+			add(p)
+		}
+
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !typ.Field(i).IsExported() {
+				continue
+			}
+			for _, field := range shrink(v.Field(i)) {
+				c := reflect.New(typ).Elem()
+				c.Set(v)
+				c.Field(i).Set(field)
+				add(c)
+			}
+		}
+	}
+
+	return candidates
+}
+
+func sign(n int64) int64 {
+	if n < 0 {
+		return -1
+	}
+	return 1
+}
+
+// copySlice returns a fresh slice holding v[from:to].
+func copySlice(v reflect.Value, from, to int) reflect.Value {
+	c := reflect.MakeSlice(v.Type(), to-from, to-from)
+	reflect.Copy(c, v.Slice(from, to))
+	return c
+}
+
+// formatArgs renders one argument per line.
+func formatArgs(args []reflect.Value) string {
+	lines := make([]string, len(args))
+	for i, arg := range args {
+		lines[i] = fmt.Sprintf("  arg %d (%s): %#v", i, arg.Type(), arg.Interface())
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,110 @@
+package quick
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+type recordingT struct {
+	errors []string
+}
+
+func (r *recordingT) Helper()      {}
+func (r *recordingT) Name() string { return "TestQuick" }
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// TestCheckPassingProperty tests that a property holding for all inputs passes.
+func TestCheckPassingProperty(t *testing.T) {
+	Check(t, func(a, b int) bool {
+		return a+b == b+a
+	})
+	Check(t, func(s string) error {
+		if strings.ToUpper(strings.ToLower(s)) != strings.ToUpper(s) {
+			return errors.New("case round trip changed the string")
+		}
+		return nil
+	})
+}
+
+// TestCheckShrinksInteger tests that a failing integer is shrunk to the boundary.
+func TestCheckShrinksInteger(t *testing.T) {
+	rec := &recordingT{}
+	Check(rec, func(n int) bool { return n < 10 })
+
+	if len(rec.errors) != 1 {
+		t.Fatalf("Expected 1 failure, got %v", rec.errors)
+	}
+	if !strings.Contains(rec.errors[0], "arg 0 (int): 10\n") {
+		t.Errorf("Expected the counterexample to shrink to 10, got:\n%s", rec.errors[0])
+	}
+}
+
+// TestCheckShrinksSlice tests that a failing slice is shrunk in length and elements.
+func TestCheckShrinksSlice(t *testing.T) {
+	rec := &recordingT{}
+	Check(rec, func(xs []int) error {
+		if len(xs) >= 2 {
+			return fmt.Errorf("got %d elements", len(xs))
+		}
+		return nil
+	})
+
+	if len(rec.errors) != 1 {
+		t.Fatalf("Expected 1 failure, got %v", rec.errors)
+	}
+	if !strings.Contains(rec.errors[0], "arg 0 ([]int): []int{0, 0}") {
+		t.Errorf("Expected the counterexample to shrink to two zeros, got:\n%s", rec.errors[0])
+	}
+	if !strings.Contains(rec.errors[0], "Failure: got 2 elements") {
+		t.Errorf("Expected the failure of the shrunk input, got:\n%s", rec.errors[0])
+	}
+}
+
+// TestCheckReportsPanics tests that a panicking property counts as failing.
+func TestCheckReportsPanics(t *testing.T) {
+	rec := &recordingT{}
+	Check(rec, func(s string) bool {
+		if strings.Contains(s, "a") {
+			panic("found an a")
+		}
+		return true
+	}, Config{MaxCount: 1000})
+
+	if len(rec.errors) != 1 {
+		t.Fatalf("Expected 1 failure, got %v", rec.errors)
+	}
+	if !strings.Contains(rec.errors[0], `arg 0 (string): "a"`) || !strings.Contains(rec.errors[0], "panic: found an a") {
+		t.Errorf("Expected a minimal panicking input, got:\n%s", rec.errors[0])
+	}
+}
+
+// TestCheckRejectsInvalidProperty tests that non-property values are reported.
+func TestCheckRejectsInvalidProperty(t *testing.T) {
+	rec := &recordingT{}
+	Check(rec, func(n int) int { return n })
+	Check(rec, 42)
+
+	if len(rec.errors) != 2 || !strings.Contains(rec.errors[0], "must return a bool or an error") {
+		t.Errorf("Expected invalid properties to be reported, got %v", rec.errors)
+	}
+}
+
+// TestShrinkStringKeepsRunes tests that shrinking multibyte strings yields
+// valid UTF-8 candidates.
+func TestShrinkStringKeepsRunes(t *testing.T) {
+	candidates := shrink(reflect.ValueOf("héllo, 世界"))
+	if len(candidates) == 0 {
+		t.Fatal("Expected shrink candidates")
+	}
+	for _, c := range candidates {
+		if !utf8.ValidString(c.String()) {
+			t.Errorf("Expected valid UTF-8 candidates, got %q", c.String())
+		}
+	}
+}
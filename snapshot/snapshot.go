@@ -0,0 +1,74 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/g-restante/GopeherKit.Test/assert"
+	"github.com/g-restante/GopeherKit.Test/internal/diff"
+)
+
+// UpdateEnv is the environment variable that, when set to 1, makes Match
+// rewrite snapshot files with the current values instead of comparing them.
+// An environment variable is used rather than a flag so that it cannot clash
+// with flags of the test binary, and works with go test ./... across packages.
+const UpdateEnv = "GOPHERKIT_UPDATE_SNAPSHOTS"
+
+// Dir is the directory, relative to the package under test, where snapshots are stored.
+var Dir = filepath.Join("testdata", "snapshots")
+
+// TestingT is the subset of *testing.T used by Match.
+type TestingT interface {
+	assert.TestingT
+	Name() string
+}
+
+// Match compares value against the snapshot stored for the running test.
+// The value is serialized as indented JSON. If no snapshot exists yet, or the
+// tests run with GOPHERKIT_UPDATE_SNAPSHOTS=1, the snapshot file is (re)written
+// instead.
+func Match(t TestingT, value any) {
+	t.Helper()
+
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		t.Errorf("failed to serialize snapshot value: %v", err)
+		return
+	}
+	data = append(data, '\n')
+
+	path := Path(t.Name())
+	stored, err := os.ReadFile(path)
+	if os.Getenv(UpdateEnv) == "1" || errors.Is(err, os.ErrNotExist) {
+		if err := write(path, data); err != nil {
+			t.Errorf("failed to write snapshot %s: %v", path, err)
+		}
+		return
+	}
+	if err != nil {
+		t.Errorf("failed to read snapshot %s: %v", path, err)
+		return
+	}
+
+	if string(stored) != string(data) {
+		t.Errorf("value does not match snapshot %s (run with "+UpdateEnv+"=1 to refresh)\nDiff (-snapshot +actual):\n%s", path, diff.Lines(string(stored), string(data)))
+	}
+}
+
+// Path returns the snapshot file path for the given test name. Slashes
+// introduced by subtests are replaced so every snapshot lives directly in Dir.
+func Path(testName string) string {
+	name := strings.NewReplacer("/", "_", "\\", "_", ":", "_").Replace(testName)
+	return filepath.Join(Dir, name+".snap")
+}
+
+// write stores data at path, creating directories as needed.
+func write(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,119 @@
+package snapshot
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type user struct {
+	ID    string
+	Name  string
+	Email string
+}
+
+// namedT is a TestingT with a fixed name that records failures.
+type namedT struct {
+	name   string
+	errors []string
+}
+
+func (n *namedT) Helper() {}
+
+func (n *namedT) Name() string { return n.name }
+
+func (n *namedT) Errorf(format string, args ...any) {
+	n.errors = append(n.errors, fmt.Sprintf(format, args...))
+}
+
+// useTempDir points Dir at a temporary directory for the duration of the test.
+func useTempDir(t *testing.T) {
+	original := Dir
+	Dir = t.TempDir()
+	t.Cleanup(func() { Dir = original })
+}
+
+// TestPath tests that subtest slashes are sanitized.
+func TestPath(t *testing.T) {
+	got := Path("TestListUsers/filter by name")
+	want := filepath.Join(Dir, "TestListUsers_filter by name.snap")
+
+	if got != want {
+		t.Errorf("Expected path %q, got %q", want, got)
+	}
+}
+
+// TestMatchCreatesSnapshot tests that a missing snapshot is written.
+func TestMatchCreatesSnapshot(t *testing.T) {
+	useTempDir(t)
+
+	rec := &namedT{name: "TestListUsers/all"}
+	Match(rec, []*user{{ID: "1", Name: "John Doe", Email: "john@example.com"}})
+
+	if len(rec.errors) > 0 {
+		t.Fatalf("Expected no failure, got %v", rec.errors)
+	}
+
+	content, err := os.ReadFile(Path(rec.name))
+	if err != nil {
+		t.Fatalf("Expected snapshot file to be created: %v", err)
+	}
+
+	if !strings.Contains(string(content), `"Name": "John Doe"`) {
+		t.Errorf("Expected snapshot to contain the serialized user, got %s", content)
+	}
+}
+
+// TestMatchExistingSnapshot tests that an equal value matches the stored snapshot.
+func TestMatchExistingSnapshot(t *testing.T) {
+	useTempDir(t)
+
+	users := []*user{{ID: "1", Name: "John Doe"}, {ID: "2", Name: "Jane Smith"}}
+
+	rec := &namedT{name: "TestMatch"}
+	Match(rec, users)
+	Match(rec, users)
+
+	if len(rec.errors) > 0 {
+		t.Errorf("Expected no failure, got %v", rec.errors)
+	}
+}
+
+// TestMatchMismatch tests that a differing value is reported.
+func TestMatchMismatch(t *testing.T) {
+	useTempDir(t)
+
+	rec := &namedT{name: "TestMismatch"}
+	Match(rec, []*user{{ID: "1", Name: "John Doe"}})
+	Match(rec, []*user{{ID: "1", Name: "Jane Doe"}})
+
+	if len(rec.errors) != 1 {
+		t.Fatalf("Expected exactly one failure, got %v", rec.errors)
+	}
+
+	if !strings.Contains(rec.errors[0], "GOPHERKIT_UPDATE_SNAPSHOTS=1") || !strings.Contains(rec.errors[0], `+     "Name": "Jane Doe"`) {
+		t.Errorf("Expected failure with diff and update hint, got %q", rec.errors[0])
+	}
+}
+
+// TestMatchUpdate tests that the update environment variable rewrites a
+// differing snapshot instead of failing.
+func TestMatchUpdate(t *testing.T) {
+	useTempDir(t)
+
+	rec := &namedT{name: "TestUpdate"}
+	Match(rec, []*user{{ID: "1", Name: "John Doe"}})
+
+	t.Setenv(UpdateEnv, "1")
+	Match(rec, []*user{{ID: "1", Name: "Jane Doe"}})
+	if len(rec.errors) > 0 {
+		t.Fatalf("Expected the snapshot to be rewritten, got %v", rec.errors)
+	}
+
+	content, err := os.ReadFile(Path("TestUpdate"))
+	if err != nil || !strings.Contains(string(content), `"Name": "Jane Doe"`) {
+		t.Errorf("Expected the updated value to be stored, got %s (%v)", content, err)
+	}
+}
@@ -0,0 +1,16 @@
+package testutil
+
+import (
+	"context"
+	"testing"
+)
+
+// WithCancelledContext returns a context that is already cancelled, for
+// checking that code under test gives up instead of doing work.
+func WithCancelledContext(t *testing.T) context.Context {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
@@ -0,0 +1,21 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// TestWithCancelledContext tests that the returned context is already done.
+func TestWithCancelledContext(t *testing.T) {
+	ctx := WithCancelledContext(t)
+
+	select {
+	case <-ctx.Done():
+	default:
+		t.Fatal("Expected the context to be done")
+	}
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", ctx.Err())
+	}
+}
@@ -0,0 +1,44 @@
+package testutil
+
+import (
+	"bytes"
+	"log"
+	"sync"
+	"testing"
+)
+
+// LogBuffer collects log output. It is safe for concurrent use, so it can be
+// read while goroutines under test are still logging.
+type LogBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// Write appends p to the buffer.
+func (b *LogBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// String returns everything logged so far.
+func (b *LogBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// CaptureLog redirects the output of the standard logger to the returned
+// buffer until the test finishes. Tests that capture the standard logger
+// must not run in parallel with each other.
+func CaptureLog(t *testing.T) *LogBuffer {
+	t.Helper()
+
+	buf := &LogBuffer{}
+	previous := log.Writer()
+	log.SetOutput(buf)
+	t.Cleanup(func() {
+		log.SetOutput(previous)
+	})
+	return buf
+}
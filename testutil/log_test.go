@@ -0,0 +1,39 @@
+package testutil
+
+import (
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestCaptureLog tests capturing concurrent log lines and restoring the output.
+func TestCaptureLog(t *testing.T) {
+	t.Run("capture", func(t *testing.T) {
+		buf := CaptureLog(t)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				log.Printf("saving user %d", i)
+				_ = buf.String()
+			}(i)
+		}
+		wg.Wait()
+
+		output := buf.String()
+		if lines := strings.Count(output, "\n"); lines != 10 {
+			t.Errorf("Expected 10 log lines, got %d:\n%s", lines, output)
+		}
+		if !strings.Contains(output, "saving user 7") {
+			t.Errorf("Expected a captured log line, got:\n%s", output)
+		}
+	})
+
+	if log.Writer() != os.Stderr {
+		t.Error("Expected the standard logger output to be restored after the test")
+	}
+}
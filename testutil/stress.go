@@ -0,0 +1,53 @@
+package testutil
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"testing"
+)
+
+// Stress runs fn iterations times in each of goroutines concurrent workers
+// and waits for all of them. A panic in a worker stops that worker and is
+// reported as a test failure with the worker's stack. Run it with -race to
+// surface data races in fn or in the mocks it uses.
+func Stress(t *testing.T, goroutines, iterations int, fn func()) {
+	t.Helper()
+
+	for _, failure := range stress(goroutines, iterations, fn) {
+		t.Error(failure)
+	}
+}
+
+// stress runs the workers of Stress and describes each panic.
+func stress(goroutines, iterations int, fn func()) []string {
+	var wg sync.WaitGroup
+	failures := make(chan string, goroutines)
+
+	for worker := 0; worker < goroutines; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+
+			iteration := 0
+			defer func() {
+				if r := recover(); r != nil {
+					failures <- fmt.Sprintf("worker %d panicked in iteration %d: %v\n%s", worker, iteration, r, debug.Stack())
+				}
+			}()
+
+			for ; iteration < iterations; iteration++ {
+				fn()
+			}
+		}(worker)
+	}
+
+	wg.Wait()
+	close(failures)
+
+	var reports []string
+	for failure := range failures {
+		reports = append(reports, failure)
+	}
+	return reports
+}
@@ -0,0 +1,54 @@
+package testutil
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/g-restante/GopeherKit.Test/mock"
+)
+
+// TestStressRunsEveryIteration tests that fn runs goroutines*iterations times.
+func TestStressRunsEveryIteration(t *testing.T) {
+	var count int64
+	Stress(t, 8, 100, func() {
+		atomic.AddInt64(&count, 1)
+	})
+
+	if count != 800 {
+		t.Errorf("Expected 800 runs, got %d", count)
+	}
+}
+
+// TestStressConcurrentMock tests that a mock can be called from many
+// goroutines; under -race an unsafe mock would be reported.
+func TestStressConcurrentMock(t *testing.T) {
+	m := mock.NewMock(t)
+	m.On("FindByID", "1").Return("alice", nil)
+
+	Stress(t, 8, 50, func() {
+		m.Called("FindByID", "1")
+		m.GetCallCount("FindByID")
+	})
+
+	if got := m.GetCallCount("FindByID"); got != 400 {
+		t.Errorf("Expected 400 calls, got %d", got)
+	}
+}
+
+// TestStressReportsPanics tests that a panicking worker is reported with its stack.
+func TestStressReportsPanics(t *testing.T) {
+	var calls int64
+	failures := stress(4, 10, func() {
+		if atomic.AddInt64(&calls, 1) == 5 {
+			panic("boom")
+		}
+	})
+
+	if len(failures) != 1 {
+		t.Fatalf("Expected 1 failure, got %d: %v", len(failures), failures)
+	}
+	if !strings.Contains(failures[0], "panicked in iteration") || !strings.Contains(failures[0], "boom") || !strings.Contains(failures[0], "goroutine ") {
+		t.Errorf("Expected the panic value and stack, got:\n%s", failures[0])
+	}
+}
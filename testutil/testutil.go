@@ -0,0 +1,24 @@
+package testutil
+
+import (
+	"testing"
+)
+
+// TestCase is a named subtest to be run by RunParallel.
+type TestCase struct {
+	Name string
+	Func func(t *testing.T)
+}
+
+// RunParallel runs each case as a parallel subtest of t.
+func RunParallel(t *testing.T, cases []TestCase) {
+	t.Helper()
+
+	for _, tc := range cases {
+		tc := tc // capture the loop variable for the parallel closure
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			tc.Func(t)
+		})
+	}
+}
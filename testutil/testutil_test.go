@@ -0,0 +1,44 @@
+package testutil
+
+import (
+	"sort"
+	"sync"
+	"testing"
+)
+
+// TestRunParallel tests that every case runs exactly once with its own function.
+func TestRunParallel(t *testing.T) {
+	var mu sync.Mutex
+	var ran []string
+
+	names := []string{"first", "second", "third", "fourth"}
+	var cases []TestCase
+	for _, name := range names {
+		name := name
+		cases = append(cases, TestCase{
+			Name: name,
+			Func: func(t *testing.T) {
+				mu.Lock()
+				defer mu.Unlock()
+				ran = append(ran, name)
+			},
+		})
+	}
+
+	// Parallel subtests finish before their parent t.Run returns.
+	t.Run("cases", func(t *testing.T) {
+		RunParallel(t, cases)
+	})
+
+	sort.Strings(ran)
+	expected := []string{"first", "fourth", "second", "third"}
+	if len(ran) != len(expected) {
+		t.Fatalf("Expected %d cases to run, got %v", len(expected), ran)
+	}
+	for i := range expected {
+		if ran[i] != expected[i] {
+			t.Errorf("Expected cases %v to run, got %v", expected, ran)
+			break
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package testutil
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Watchdog fails the test and dumps the stacks of all goroutines if the test
+// has not finished within d. The dump is written to stderr as soon as the
+// deadline passes, so a hung test shows where every goroutine is blocked
+// instead of waiting for the go test timeout. The watchdog cannot unblock the
+// test itself; it is stopped when the test finishes.
+func Watchdog(t *testing.T, d time.Duration) {
+	t.Helper()
+
+	// done keeps a timer firing during cleanup from reporting on a test
+	// that has already finished.
+	var mu sync.Mutex
+	done := false
+
+	timer := time.AfterFunc(d, func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if done {
+			return
+		}
+
+		report := watchdogReport(t.Name(), d, allStacks())
+		fmt.Fprintln(os.Stderr, report)
+		t.Error(report)
+	})
+	t.Cleanup(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		done = true
+		timer.Stop()
+	})
+}
+
+// allStacks returns the stack traces of all goroutines.
+func allStacks() []byte {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// watchdogReport describes a test that exceeded its deadline, with the
+// goroutine stacks indented below a count of the goroutines.
+func watchdogReport(name string, d time.Duration, stacks []byte) string {
+	dump := strings.TrimSpace(string(stacks))
+	goroutines := strings.Count(dump, "goroutine ")
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "watchdog: %s did not finish within %s\n", name, d)
+	fmt.Fprintf(&b, "Goroutines (%d):\n", goroutines)
+	for _, line := range strings.Split(dump, "\n") {
+		b.WriteString("    " + line + "\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,43 @@
+package testutil
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestWatchdogQuickTest tests that a test finishing in time is not failed.
+func TestWatchdogQuickTest(t *testing.T) {
+	var failed bool
+	t.Run("quick", func(t *testing.T) {
+		Watchdog(t, 50*time.Millisecond)
+		failed = t.Failed()
+	})
+
+	// Give a leaked timer the chance to fire before checking.
+	time.Sleep(100 * time.Millisecond)
+	if failed {
+		t.Error("Expected a quick test not to trigger the watchdog")
+	}
+}
+
+// TestWatchdogReport tests the formatting of the stack dump.
+func TestWatchdogReport(t *testing.T) {
+	stacks := "goroutine 1 [chan receive]:\nmain.wait()\n\nmain.go:10\n\ngoroutine 7 [running]:\nmain.work()\n"
+	report := watchdogReport("TestHang", 2*time.Second, []byte(stacks))
+
+	for _, want := range []string{
+		"watchdog: TestHang did not finish within 2s\n",
+		"Goroutines (2):\n",
+		"    goroutine 1 [chan receive]:\n    main.wait()\n",
+		"    goroutine 7 [running]:\n    main.work()",
+	} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Expected report to contain %q, got:\n%s", want, report)
+		}
+	}
+
+	if !strings.Contains(string(allStacks()), "TestWatchdogReport") {
+		t.Error("Expected the stack dump to include the running test")
+	}
+}